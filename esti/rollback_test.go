@@ -169,6 +169,68 @@ func TestResetPath(t *testing.T) {
 	require.False(t, f, "object not found")
 }
 
+func TestResetPathMixedChanges(t *testing.T) {
+	ctx, _, repo := setupTest(t)
+	defer tearDownTest(repo)
+	const (
+		prefix       = "prefix/"
+		addedPath    = prefix + "added.txt"
+		modifiedPath = prefix + "modified.txt"
+		deletedPath  = prefix + "deleted.txt"
+		outsidePath  = "outside.txt"
+	)
+
+	// commit the files that will be modified and deleted
+	_, modifiedContent := uploadFileRandomData(ctx, t, repo, mainBranch, modifiedPath)
+	_, deletedContent := uploadFileRandomData(ctx, t, repo, mainBranch, deletedPath)
+	commitResp, err := client.CommitWithResponse(ctx, repo, mainBranch, &apigen.CommitParams{}, apigen.CommitJSONRequestBody{
+		Message: "resetPathMixedChanges",
+	})
+	require.NoError(t, err, "failed to commit changes")
+	require.NoErrorf(t, verifyResponse(commitResp.HTTPResponse, commitResp.Body),
+		"failed to commit changes repo %s branch %s", repo, mainBranch)
+
+	// add, modify and delete under the prefix, and add outside it
+	uploadFileRandomData(ctx, t, repo, mainBranch, addedPath)
+	uploadFileRandomData(ctx, t, repo, mainBranch, modifiedPath)
+	uploadFileRandomData(ctx, t, repo, mainBranch, outsidePath)
+	deleteResp, err := client.DeleteObjectWithResponse(ctx, repo, mainBranch, &apigen.DeleteObjectParams{
+		Path: deletedPath,
+	})
+	require.NoError(t, err, "failed to delete file")
+	require.NoErrorf(t, verifyResponse(deleteResp.HTTPResponse, deleteResp.Body),
+		"failed to delete file %s repo %s branch %s", deletedPath, repo, mainBranch)
+
+	resetPrefix := prefix
+	reset := apigen.ResetCreation{
+		Path: &resetPrefix,
+		Type: "common_prefix",
+	}
+	resetResp, err := client.ResetBranchWithResponse(ctx, repo, mainBranch, apigen.ResetBranchJSONRequestBody(reset))
+	require.NoError(t, err, "failed to reset")
+	require.NoErrorf(t, verifyResponse(resetResp.HTTPResponse, resetResp.Body),
+		"failed to reset prefix %s repo %s branch %s", resetPrefix, repo, mainBranch)
+
+	// added file is gone
+	f, err := objectFound(ctx, repo, mainBranch, addedPath)
+	require.NoError(t, err)
+	require.False(t, f, "added object should not be found after reset")
+
+	// modified and deleted files are back to their committed content
+	for path, content := range map[string]string{modifiedPath: modifiedContent, deletedPath: deletedContent} {
+		getObjResp, err := client.GetObjectWithResponse(ctx, repo, mainBranch, &apigen.GetObjectParams{Path: path})
+		require.NoError(t, err, "failed to get object")
+		require.NoErrorf(t, verifyResponse(getObjResp.HTTPResponse, getObjResp.Body),
+			"failed to get object repo %s branch %s path %s", repo, mainBranch, path)
+		require.Equal(t, content, string(getObjResp.Body), "path: %s", path)
+	}
+
+	// changes outside the prefix are kept
+	f, err = objectFound(ctx, repo, mainBranch, outsidePath)
+	require.NoError(t, err)
+	require.True(t, f, "object outside the prefix should be kept")
+}
+
 func TestResetObject(t *testing.T) {
 	ctx, _, repo := setupTest(t)
 	defer tearDownTest(repo)
@@ -2731,9 +2731,15 @@ func (c *Controller) handleAPIErrorCallback(ctx context.Context, w http.Response
 	case errors.Is(err, graveler.ErrTooManyTries):
 		log.Debug("Retried too many times")
 		cb(w, r, http.StatusLocked, "Too many attempts, try again later")
+	case errors.Is(err, graveler.ErrRateLimited):
+		log.Debug("Rate limited")
+		cb(w, r, http.StatusTooManyRequests, err)
 	case errors.Is(err, kv.ErrSlowDown):
 		log.Debug("KV Throttling")
 		cb(w, r, http.StatusServiceUnavailable, "Throughput exceeded. Slow down and retry")
+	case errors.Is(err, graveler.ErrRepositoryInMaintenance):
+		log.Debug("Repository in maintenance")
+		cb(w, r, http.StatusServiceUnavailable, err)
 	case errors.Is(err, graveler.ErrPreconditionFailed):
 		log.Debug("Precondition failed")
 		cb(w, r, http.StatusPreconditionFailed, "Precondition failed")
@@ -2752,6 +2752,27 @@ func TestController_ObjectsUploadObjectHandler(t *testing.T) {
 			t.Fatalf("got unexpected etag: %s - expected %s", etag, httputil.ETag(expectedEtag))
 		}
 	})
+
+	t.Run("repository in maintenance", func(t *testing.T) {
+		maintenanceRepo := testUniqueRepoName()
+		path := "foo/bar"
+		_, err := deps.catalog.CreateRepository(ctx, maintenanceRepo, onBlock(deps, "bucket/prefix"), "main", false)
+		testutil.Must(t, err)
+		testutil.Must(t, deps.catalog.SetRepositoryMaintenance(ctx, maintenanceRepo, true, "migration"))
+
+		resp, err := uploadObjectHelper(t, ctx, clt, path, strings.NewReader(content), maintenanceRepo, "main")
+		testutil.Must(t, err)
+		if resp.StatusCode() != http.StatusServiceUnavailable {
+			t.Fatalf("Expected 503 for UploadObject on repository in maintenance, got %d", resp.StatusCode())
+		}
+		if !strings.Contains(string(resp.Body), "migration") {
+			t.Fatalf("Expected maintenance reason in error, got %s", string(resp.Body))
+		}
+
+		testutil.Must(t, deps.catalog.SetRepositoryMaintenance(ctx, maintenanceRepo, false, ""))
+		resp, err = uploadObjectHelper(t, ctx, clt, path, strings.NewReader(content), maintenanceRepo, "main")
+		verifyResponseOK(t, resp, err)
+	})
 }
 
 func TestController_ObjectsStageObjectHandler(t *testing.T) {
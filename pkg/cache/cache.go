@@ -18,6 +18,8 @@ type SetFnWithExpiry func() (v interface{}, expiry time.Duration, err error)
 type Cache interface {
 	GetOrSet(k interface{}, setFn SetFn) (v interface{}, err error)
 	GetOrSetWithExpiry(k interface{}, setFn SetFnWithExpiry) (v interface{}, err error)
+	// Delete drops k from the cache, the next GetOrSet of k calls its setFn
+	Delete(k interface{})
 }
 
 type GetSetCache struct {
@@ -62,6 +64,10 @@ func (c *GetSetCache) GetOrSetWithExpiry(k interface{}, setFn SetFnWithExpiry) (
 	})
 }
 
+func (c *GetSetCache) Delete(k interface{}) {
+	c.lru.Remove(k)
+}
+
 func NewJitterFn(jitter time.Duration) JitterFn {
 	if jitter <= 0 {
 		return func() time.Duration {
@@ -184,3 +184,23 @@ func TestCacheGetSetWithExpiry(t *testing.T) {
 	}
 	slowSpy.ResetCalled()
 }
+
+func TestCacheDelete(t *testing.T) {
+	const value = 17
+	c := cache.NewCache(10, time.Hour, cache.NewJitterFn(time.Millisecond))
+	spy := NewSpy(value, 0)
+	setFn := spy.MakeSetFn()
+
+	if _, err := c.GetOrSetWithExpiry("key", setFn); err != nil {
+		t.Fatalf("Failed to GetSet key: %s", err)
+	}
+	spy.ResetCalled()
+
+	c.Delete("key")
+	if _, err := c.GetOrSetWithExpiry("key", setFn); err != nil {
+		t.Fatalf("Failed to GetSet key: %s", err)
+	}
+	if !spy.Called() {
+		t.Error("Expected to call SetFn after delete")
+	}
+}
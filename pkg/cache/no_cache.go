@@ -12,3 +12,5 @@ func (m *noCache) GetOrSetWithExpiry(_ interface{}, setFn SetFnWithExpiry) (v in
 	v, _, err = setFn()
 	return v, err
 }
+
+func (m *noCache) Delete(_ interface{}) {}
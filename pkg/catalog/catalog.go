@@ -8,6 +8,7 @@ import (
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -15,6 +16,7 @@ import (
 	"net/url"
 	"os"
 	"reflect"
+	"sort"
 	"strings"
 	"time"
 
@@ -35,6 +37,7 @@ import (
 	"github.com/treeverse/lakefs/pkg/graveler/settings"
 	"github.com/treeverse/lakefs/pkg/graveler/sstable"
 	"github.com/treeverse/lakefs/pkg/graveler/staging"
+	"github.com/treeverse/lakefs/pkg/httputil"
 	"github.com/treeverse/lakefs/pkg/ident"
 	"github.com/treeverse/lakefs/pkg/ingest/store"
 	"github.com/treeverse/lakefs/pkg/kv"
@@ -45,6 +48,7 @@ import (
 	"github.com/treeverse/lakefs/pkg/validator"
 	"go.uber.org/atomic"
 	"go.uber.org/ratelimit"
+	"golang.org/x/time/rate"
 	"google.golang.org/protobuf/reflect/protoreflect"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
@@ -180,6 +184,45 @@ type LogParams struct {
 	FirstParent   bool
 	Since         *time.Time
 	StopAt        string
+	// MergesOnly lists only merge commits, commits with more than one parent
+	MergesOnly bool
+	// Metadata lists only commits that have all these metadata key/values. Matching scans the log, so it is
+	// meant for interactive use. A log with any of the commit filters scans up to LogFilterMaxCommits commits.
+	Metadata map[string]string
+	// Committer lists only commits by this committer
+	Committer string
+	// Until lists only commits created at or before this time. Unlike Since it does not stop the log.
+	Until *time.Time
+	// MessageContains lists only commits whose message contains this string
+	MessageContains string
+}
+
+// hasCommitFilters reports whether any of the commit filters of the params is set
+func (p LogParams) hasCommitFilters() bool {
+	return p.MergesOnly || len(p.Metadata) > 0 || p.Committer != "" || p.Until != nil || p.MessageContains != ""
+}
+
+// filterCommit reports whether commit matches the commit filters of the params: MergesOnly, Metadata, Committer,
+// Until and MessageContains
+func (p LogParams) filterCommit(commit *graveler.CommitRecord) bool {
+	if p.MergesOnly && len(commit.Parents) <= NumberOfParentsOfNonMergeCommit {
+		return false
+	}
+	if p.Committer != "" && commit.Committer != p.Committer {
+		return false
+	}
+	if p.Until != nil && commit.CreationDate.After(*p.Until) {
+		return false
+	}
+	if p.MessageContains != "" && !strings.Contains(commit.Message, p.MessageContains) {
+		return false
+	}
+	for k, v := range p.Metadata {
+		if commitValue, ok := commit.Metadata[k]; !ok || commitValue != v {
+			return false
+		}
+	}
+	return true
 }
 
 type ExpireResult struct {
@@ -244,6 +287,18 @@ const (
 	ListTagsLimitMax         = 1000
 	DiffLimitMax             = 1000
 	ListEntriesLimitMax      = 10000
+	WasDeletedMaxCommits     = 1000
+	ListEmptyCommitsLimitMax = 1000
+	TreeShapeMaxEntries      = 1_000_000
+	ProvenanceMaxCommits     = 1000
+	TagsRangeMaxCommits      = 100_000
+	ListWithCommitMaxCommits = 1000
+	LogFilterMaxCommits      = 100_000
+	DeleteBranchesMaxSize    = 1000
+	GetEntriesMaxSize        = 1000
+	ScanShardsMax            = 64
+	ScanShardsMaxChildren    = 10000
+	VerifyEntryMaxSize       = 5 * 1024 * 1024 * 1024
 	sharedWorkers            = 30
 	pendingTasksPerWorker    = 3
 	workersMaxDrainDuration  = 5 * time.Second
@@ -392,6 +447,7 @@ func New(ctx context.Context, cfg Config) (*Catalog, error) {
 		deleteSensor = graveler.NewDeleteSensor(cfg.Config.Graveler.CompactionSensorThreshold, cb)
 	}
 	gStore := graveler.NewGraveler(committedManager, stagingManager, refManager, gcManager, protectedBranchesManager, deleteSensor)
+	gStore.SetCommitRateLimit(rate.Limit(cfg.Config.Graveler.CommitRateLimit))
 
 	// The size of the workPool is determined by the number of workers and the number of desired pending tasks for each worker.
 	workPool := pond.New(sharedWorkers, sharedWorkers*pendingTasksPerWorker, pond.Context(ctx))
@@ -511,6 +567,39 @@ func (c *Catalog) GetRepository(ctx context.Context, repository string) (*Reposi
 	return catalogRepository, nil
 }
 
+// GetRepositoryWithHead returns the repository and the head of its default branch.
+// The branch is nil, without an error, when the default branch does not exist.
+func (c *Catalog) GetRepositoryWithHead(ctx context.Context, repository string) (*Repository, *Branch, error) {
+	repositoryID := graveler.RepositoryID(repository)
+	if err := validator.Validate([]validator.ValidateArg{
+		{Name: "repository", Value: repositoryID, Fn: graveler.ValidateRepositoryID},
+	}); err != nil {
+		return nil, nil, err
+	}
+	repo, err := c.getRepository(ctx, repository)
+	if err != nil {
+		return nil, nil, err
+	}
+	catalogRepository := &Repository{
+		Name:             repositoryID.String(),
+		StorageNamespace: repo.StorageNamespace.String(),
+		DefaultBranch:    repo.DefaultBranchID.String(),
+		CreationDate:     repo.CreationDate,
+		ReadOnly:         repo.ReadOnly,
+	}
+	branch, err := c.Store.GetBranch(ctx, repo, repo.DefaultBranchID)
+	if errors.Is(err, graveler.ErrNotFound) {
+		return catalogRepository, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return catalogRepository, &Branch{
+		Name:      repo.DefaultBranchID.String(),
+		Reference: branch.CommitID.String(),
+	}, nil
+}
+
 // DeleteRepository delete a repository
 func (c *Catalog) DeleteRepository(ctx context.Context, repository string, opts ...graveler.SetOptionsFunc) error {
 	repositoryID := graveler.RepositoryID(repository)
@@ -570,6 +659,71 @@ func (c *Catalog) DeleteRepositoryMetadata(ctx context.Context, repository strin
 	})
 }
 
+// GetDefaultBranch returns the repository default branch
+func (c *Catalog) GetDefaultBranch(ctx context.Context, repositoryID string) (string, error) {
+	if err := validator.Validate([]validator.ValidateArg{
+		{Name: "repository", Value: repositoryID, Fn: graveler.ValidateRepositoryID},
+	}); err != nil {
+		return "", err
+	}
+	repository, err := c.getRepository(ctx, repositoryID)
+	if err != nil {
+		return "", err
+	}
+	return repository.DefaultBranchID.String(), nil
+}
+
+// SetDefaultBranch sets the repository default branch to branch, which must exist.
+// Repository records are cached, so readers may see the previous default branch until the cache entry expires.
+func (c *Catalog) SetDefaultBranch(ctx context.Context, repositoryID, branch string) error {
+	branchID := graveler.BranchID(branch)
+	if err := validator.Validate([]validator.ValidateArg{
+		{Name: "repository", Value: repositoryID, Fn: graveler.ValidateRepositoryID},
+		{Name: "branch", Value: branchID, Fn: graveler.ValidateBranchID},
+	}); err != nil {
+		return err
+	}
+	repository, err := c.getRepository(ctx, repositoryID)
+	if err != nil {
+		return err
+	}
+	if err := c.checkMaintenance(repository); err != nil {
+		return err
+	}
+	return c.Store.SetDefaultBranch(ctx, repository, branchID)
+}
+
+// SetRepositoryMaintenance turns maintenance mode of a repository on or off. While on, write operations on the
+// repository fail with ErrRepositoryInMaintenance carrying reason, and reads are served as usual. Unlike a read-only
+// repository, maintenance mode is meant to be temporary, e.g. to quiesce writes during a migration.
+// The mode is kept on the repository record, other lakeFS instances apply it once their cached record expires.
+func (c *Catalog) SetRepositoryMaintenance(ctx context.Context, repositoryID string, on bool, reason string) error {
+	if err := validator.Validate([]validator.ValidateArg{
+		{Name: "repository", Value: repositoryID, Fn: graveler.ValidateRepositoryID},
+	}); err != nil {
+		return err
+	}
+	if on && reason == "" {
+		return fmt.Errorf("maintenance reason: %w", graveler.ErrInvalidValue)
+	}
+	repository, err := c.getRepository(ctx, repositoryID)
+	if err != nil {
+		return err
+	}
+	if !on {
+		reason = ""
+	}
+	return c.Store.SetRepositoryMaintenance(ctx, repository, reason)
+}
+
+// checkMaintenance returns ErrRepositoryInMaintenance, with the reason set for it, if repository is in maintenance mode
+func (c *Catalog) checkMaintenance(repository *graveler.RepositoryRecord) error {
+	if repository.MaintenanceReason == "" {
+		return nil
+	}
+	return fmt.Errorf("%w: %s", graveler.ErrRepositoryInMaintenance, repository.MaintenanceReason)
+}
+
 // ListRepositories list repository information, the bool returned is true when more repositories can be listed.
 // In this case, pass the last repository name as 'after' on the next call to ListRepositories
 func (c *Catalog) ListRepositories(ctx context.Context, limit int, prefix, after string) ([]*Repository, bool, error) {
@@ -629,6 +783,41 @@ func (c *Catalog) ListRepositories(ctx context.Context, limit int, prefix, after
 	return repos, hasMore, nil
 }
 
+// ReposByStorageNamespace returns the repositories using storageNamespace. A namespace is expected to be used by a
+// single repository, more than one result means the namespace is shared by mistake.
+func (c *Catalog) ReposByStorageNamespace(ctx context.Context, storageNamespace string) ([]*Repository, error) {
+	if err := validator.Validate([]validator.ValidateArg{
+		{Name: "storage_namespace", Value: graveler.StorageNamespace(storageNamespace), Fn: graveler.ValidateStorageNamespace},
+	}); err != nil {
+		return nil, err
+	}
+	it, err := c.Store.ListRepositories(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get iterator: %w", err)
+	}
+	defer it.Close()
+
+	namespace := strings.TrimSuffix(storageNamespace, "/")
+	var repos []*Repository
+	for it.Next() {
+		record := it.Value()
+		if strings.TrimSuffix(record.StorageNamespace.String(), "/") != namespace {
+			continue
+		}
+		repos = append(repos, &Repository{
+			Name:             record.RepositoryID.String(),
+			StorageNamespace: record.StorageNamespace.String(),
+			DefaultBranch:    record.DefaultBranchID.String(),
+			CreationDate:     record.CreationDate,
+			ReadOnly:         record.ReadOnly,
+		})
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	return repos, nil
+}
+
 func (c *Catalog) GetStagingToken(ctx context.Context, repositoryID string, branch string) (*string, error) {
 	branchID := graveler.BranchID(branch)
 	if err := validator.Validate([]validator.ValidateArg{
@@ -657,7 +846,7 @@ func (c *Catalog) CreateBranch(ctx context.Context, repositoryID string, branch
 	sourceRef := graveler.Ref(sourceBranch)
 	if err := validator.Validate([]validator.ValidateArg{
 		{Name: "repository", Value: repositoryID, Fn: graveler.ValidateRepositoryID},
-		{Name: "branch", Value: branchID, Fn: graveler.ValidateBranchID},
+		{Name: "branch", Value: branchID, Fn: graveler.ValidateNewBranchID},
 		{Name: "ref", Value: sourceRef, Fn: graveler.ValidateRef},
 	}); err != nil {
 		if errors.Is(err, graveler.ErrInvalidBranchID) {
@@ -669,6 +858,9 @@ func (c *Catalog) CreateBranch(ctx context.Context, repositoryID string, branch
 	if err != nil {
 		return nil, err
 	}
+	if err := c.checkMaintenance(repository); err != nil {
+		return nil, err
+	}
 	if err := c.checkCommitIDDuplication(ctx, repository, graveler.CommitID(branchID)); err != nil {
 		return nil, err
 	}
@@ -701,6 +893,80 @@ func (c *Catalog) CreateBranch(ctx context.Context, repositoryID string, branch
 	return catalogCommitLog, nil
 }
 
+// CreateBranchWithUncommitted creates branch from sourceBranch like CreateBranch, and copies the uncommitted changes
+// of sourceBranch to the new branch, so it starts from the working state of the source. The copy is not atomic with
+// respect to writes on sourceBranch while it runs. If the copy fails, the new branch is deleted.
+func (c *Catalog) CreateBranchWithUncommitted(ctx context.Context, repositoryID string, branch string, sourceBranch string, opts ...graveler.SetOptionsFunc) (*CommitLog, error) {
+	sourceBranchID := graveler.BranchID(sourceBranch)
+	if err := validator.Validate([]validator.ValidateArg{
+		{Name: "source branch", Value: sourceBranchID, Fn: graveler.ValidateBranchID},
+	}); err != nil {
+		return nil, err
+	}
+	commitLog, err := c.CreateBranch(ctx, repositoryID, branch, sourceBranch, opts...)
+	if err != nil {
+		return nil, err
+	}
+	repository, err := c.getRepository(ctx, repositoryID)
+	if err != nil {
+		return nil, err
+	}
+	branchID := graveler.BranchID(branch)
+	if err := c.copyUncommitted(ctx, repository, sourceBranchID, branchID, opts...); err != nil {
+		if delErr := c.Store.DeleteBranch(ctx, repository, branchID, opts...); delErr != nil {
+			c.log(ctx).WithError(delErr).WithField("branch", branch).Error("Failed to delete branch after failed uncommitted copy")
+		}
+		return nil, fmt.Errorf("copy uncommitted changes: %w", err)
+	}
+	return commitLog, nil
+}
+
+// copyUncommitted stages the uncommitted changes of source on destination, in batches
+func (c *Catalog) copyUncommitted(ctx context.Context, repository *graveler.RepositoryRecord, source, destination graveler.BranchID, opts ...graveler.SetOptionsFunc) error {
+	it, err := c.Store.DiffUncommitted(ctx, repository, source)
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+
+	var (
+		records []graveler.ValueRecord
+		keys    []graveler.Key
+	)
+	flush := func() error {
+		if len(records) > 0 {
+			if err := c.Store.SetBatch(ctx, repository, destination, records, opts...); err != nil {
+				return err
+			}
+			records = records[:0]
+		}
+		if len(keys) > 0 {
+			if err := c.Store.DeleteBatch(ctx, repository, destination, keys, opts...); err != nil {
+				return err
+			}
+			keys = keys[:0]
+		}
+		return nil
+	}
+	for it.Next() {
+		d := it.Value()
+		if d.Type == graveler.DiffTypeRemoved {
+			keys = append(keys, d.Key.Copy())
+		} else {
+			records = append(records, graveler.ValueRecord{Key: d.Key.Copy(), Value: d.Value})
+		}
+		if len(records) >= graveler.SetKeysMaxSize || len(keys) >= graveler.DeleteKeysMaxSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := it.Err(); err != nil {
+		return err
+	}
+	return flush()
+}
+
 func (c *Catalog) DeleteBranch(ctx context.Context, repositoryID string, branch string, opts ...graveler.SetOptionsFunc) error {
 	branchID := graveler.BranchID(branch)
 	if err := validator.Validate([]validator.ValidateArg{
@@ -713,9 +979,53 @@ func (c *Catalog) DeleteBranch(ctx context.Context, repositoryID string, branch
 	if err != nil {
 		return err
 	}
+	if err := c.checkMaintenance(repository); err != nil {
+		return err
+	}
 	return c.Store.DeleteBranch(ctx, repository, branchID, opts...)
 }
 
+// DeleteBranches deletes each of branches, up to DeleteBranchesMaxSize, and returns the names of the branches it did
+// not delete because they do not exist or are the repository default branch. These do not stop the others from being
+// deleted. Any other failure is returned as a multi-error holding a DeleteBranchError for each such branch.
+// Branches are deleted one by one and not atomically.
+func (c *Catalog) DeleteBranches(ctx context.Context, repositoryID string, branches []string, opts ...graveler.SetOptionsFunc) ([]string, error) {
+	if err := validator.Validate([]validator.ValidateArg{
+		{Name: "repository", Value: repositoryID, Fn: graveler.ValidateRepositoryID},
+	}); err != nil {
+		return nil, err
+	}
+	if len(branches) > DeleteBranchesMaxSize {
+		return nil, fmt.Errorf("branches length (%d) passed the maximum allowed(%d): %w", len(branches), DeleteBranchesMaxSize, graveler.ErrInvalidValue)
+	}
+	for i, branch := range branches {
+		if err := graveler.ValidateBranchID(graveler.BranchID(branch)); err != nil {
+			return nil, fmt.Errorf("argument branch[%d]: %w", i, err)
+		}
+	}
+	repository, err := c.getRepository(ctx, repositoryID)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.checkMaintenance(repository); err != nil {
+		return nil, err
+	}
+
+	notDeleted := make([]string, 0)
+	var m *multierror.Error
+	for _, branch := range branches {
+		err := c.Store.DeleteBranch(ctx, repository, graveler.BranchID(branch), opts...)
+		switch {
+		case err == nil:
+		case errors.Is(err, graveler.ErrNotFound), errors.Is(err, graveler.ErrDeleteDefaultBranch):
+			notDeleted = append(notDeleted, branch)
+		default:
+			m = multierror.Append(m, &DeleteBranchError{Branch: branch, Err: err})
+		}
+	}
+	return notDeleted, m.ErrorOrNil()
+}
+
 func (c *Catalog) ListBranches(ctx context.Context, repositoryID string, prefix string, limit int, after string) ([]*Branch, bool, error) {
 	if err := validator.Validate([]validator.ValidateArg{
 		{Name: "repository", Value: repositoryID, Fn: graveler.ValidateRepositoryID},
@@ -776,6 +1086,89 @@ func (c *Catalog) ListBranches(ctx context.Context, repositoryID string, prefix
 	return branches, hasMore, nil
 }
 
+// ListBranchesAtCommit returns the branches whose head is commitID, in branch order, e.g. to warn before a reset
+// moves a branch away from a commit that no other branch points at. Every branch of the repository is read.
+func (c *Catalog) ListBranchesAtCommit(ctx context.Context, repositoryID string, commitID string) ([]*Branch, error) {
+	id := graveler.CommitID(commitID)
+	if err := validator.Validate([]validator.ValidateArg{
+		{Name: "repository", Value: repositoryID, Fn: graveler.ValidateRepositoryID},
+		{Name: "commit", Value: id, Fn: graveler.ValidateCommitID},
+	}); err != nil {
+		return nil, err
+	}
+	repository, err := c.getRepository(ctx, repositoryID)
+	if err != nil {
+		return nil, err
+	}
+	it, err := c.Store.ListBranches(ctx, repository)
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+
+	branches := make([]*Branch, 0)
+	for it.Next() {
+		v := it.Value()
+		if v.CommitID != id {
+			continue
+		}
+		branches = append(branches, &Branch{
+			Name:      v.BranchID.String(),
+			Reference: v.CommitID.String(),
+		})
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	return branches, nil
+}
+
+// ListStaleBranches lists the branches whose head commit was created before inactiveSince, in branch order.
+// Uncommitted changes are not considered activity. Every branch is read along with its head commit, so the cost is
+// one commit lookup per distinct head commit in the repository.
+func (c *Catalog) ListStaleBranches(ctx context.Context, repositoryID string, inactiveSince time.Time) ([]*BranchActivity, error) {
+	if err := validator.Validate([]validator.ValidateArg{
+		{Name: "repository", Value: repositoryID, Fn: graveler.ValidateRepositoryID},
+	}); err != nil {
+		return nil, err
+	}
+	repository, err := c.getRepository(ctx, repositoryID)
+	if err != nil {
+		return nil, err
+	}
+	it, err := c.Store.ListBranches(ctx, repository)
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+
+	stale := make([]*BranchActivity, 0)
+	dates := make(map[graveler.CommitID]time.Time)
+	for it.Next() {
+		v := it.Value()
+		date, ok := dates[v.CommitID]
+		if !ok {
+			commit, err := c.Store.GetCommit(ctx, repository, v.CommitID)
+			if err != nil {
+				return nil, fmt.Errorf("branch %s: %w", v.BranchID, err)
+			}
+			date = commit.CreationDate
+			dates[v.CommitID] = date
+		}
+		if !date.Before(inactiveSince) {
+			continue
+		}
+		stale = append(stale, &BranchActivity{
+			Branch:         Branch{Name: v.BranchID.String(), Reference: v.CommitID.String()},
+			LastCommitDate: date,
+		})
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	return stale, nil
+}
+
 func (c *Catalog) BranchExists(ctx context.Context, repositoryID string, branch string) (bool, error) {
 	branchID := graveler.BranchID(branch)
 	if err := validator.Validate([]validator.ValidateArg{
@@ -831,9 +1224,44 @@ func (c *Catalog) HardResetBranch(ctx context.Context, repositoryID, branch, ref
 	if err != nil {
 		return err
 	}
+	if err := c.checkMaintenance(repository); err != nil {
+		return err
+	}
 	return c.Store.ResetHard(ctx, repository, branchID, reference, opts...)
 }
 
+// UpdateBranchHead moves branch from expectedCommitID to newCommitID, only if the branch currently points to
+// expectedCommitID. It fails with graveler.ErrPreconditionFailed if the branch head has moved, and with
+// graveler.ErrDirtyBranch if the branch has uncommitted changes.
+func (c *Catalog) UpdateBranchHead(ctx context.Context, repositoryID, branch, expectedCommitID, newCommitID string, opts ...graveler.SetOptionsFunc) error {
+	branchID := graveler.BranchID(branch)
+	expectedID := graveler.CommitID(expectedCommitID)
+	newID := graveler.CommitID(newCommitID)
+	if err := validator.Validate([]validator.ValidateArg{
+		{Name: "repository", Value: repositoryID, Fn: graveler.ValidateRepositoryID},
+		{Name: "branch", Value: branchID, Fn: graveler.ValidateBranchID},
+		{Name: "expected", Value: expectedID, Fn: graveler.ValidateCommitID},
+		{Name: "new", Value: newID, Fn: graveler.ValidateCommitID},
+	}); err != nil {
+		return err
+	}
+	repository, err := c.getRepository(ctx, repositoryID)
+	if err != nil {
+		return err
+	}
+	if err := c.checkMaintenance(repository); err != nil {
+		return err
+	}
+	for _, commitID := range []graveler.CommitID{expectedID, newID} {
+		if _, err := c.Store.GetCommit(ctx, repository, commitID); err != nil {
+			return fmt.Errorf("commit %s: %w", commitID, err)
+		}
+	}
+	opts = append(opts, graveler.WithExpectedCommitID(expectedID))
+	_, err = c.Store.UpdateBranch(ctx, repository, branchID, graveler.Ref(newID), opts...)
+	return err
+}
+
 func (c *Catalog) ResetBranch(ctx context.Context, repositoryID string, branch string, opts ...graveler.SetOptionsFunc) error {
 	branchID := graveler.BranchID(branch)
 	if err := validator.Validate([]validator.ValidateArg{
@@ -846,6 +1274,9 @@ func (c *Catalog) ResetBranch(ctx context.Context, repositoryID string, branch s
 	if err != nil {
 		return err
 	}
+	if err := c.checkMaintenance(repository); err != nil {
+		return err
+	}
 	return c.Store.Reset(ctx, repository, branchID, opts...)
 }
 
@@ -853,7 +1284,7 @@ func (c *Catalog) CreateTag(ctx context.Context, repositoryID string, tagID stri
 	tag := graveler.TagID(tagID)
 	if err := validator.Validate([]validator.ValidateArg{
 		{Name: "repository", Value: repositoryID, Fn: graveler.ValidateRepositoryID},
-		{Name: "tagID", Value: tag, Fn: graveler.ValidateTagID},
+		{Name: "tagID", Value: tag, Fn: graveler.ValidateNewTagID},
 	}); err != nil {
 		return "", err
 	}
@@ -861,6 +1292,9 @@ func (c *Catalog) CreateTag(ctx context.Context, repositoryID string, tagID stri
 	if err != nil {
 		return "", err
 	}
+	if err := c.checkMaintenance(repository); err != nil {
+		return "", err
+	}
 
 	// look for a branch with the same name to avoid reference conflict
 	if _, err := c.Store.GetBranch(ctx, repository, graveler.BranchID(tagID)); err == nil {
@@ -895,6 +1329,9 @@ func (c *Catalog) DeleteTag(ctx context.Context, repositoryID string, tagID stri
 	if err != nil {
 		return err
 	}
+	if err := c.checkMaintenance(repository); err != nil {
+		return err
+	}
 	return c.Store.DeleteTag(ctx, repository, tag, opts...)
 }
 
@@ -953,13 +1390,82 @@ func (c *Catalog) ListTags(ctx context.Context, repositoryID string, prefix stri
 	return tags, hasMore, nil
 }
 
-func (c *Catalog) GetTag(ctx context.Context, repositoryID string, tagID string) (string, error) {
-	tag := graveler.TagID(tagID)
+// ListTagsByDate lists tags by the creation date of the commits they point to, newest first, and by tag ID for tags
+// with the same date. Pass the ID and date of the last tag returned as afterID and afterDate to get the next page;
+// an empty afterID starts from the newest tag.
+// Tags are stored by ID, so every call reads all tags and the commit each one points to.
+func (c *Catalog) ListTagsByDate(ctx context.Context, repositoryID string, limit int, afterID string, afterDate time.Time) ([]*TagWithDate, bool, error) {
+	if limit < 0 || limit > ListTagsLimitMax {
+		limit = ListTagsLimitMax
+	}
 	if err := validator.Validate([]validator.ValidateArg{
-		{Name: "name", Value: repositoryID, Fn: graveler.ValidateRepositoryID},
-		{Name: "tagID", Value: tag, Fn: graveler.ValidateTagID},
+		{Name: "repository", Value: repositoryID, Fn: graveler.ValidateRepositoryID},
 	}); err != nil {
-		return "", err
+		return nil, false, err
+	}
+	repository, err := c.getRepository(ctx, repositoryID)
+	if err != nil {
+		return nil, false, err
+	}
+	it, err := c.Store.ListTags(ctx, repository)
+	if err != nil {
+		return nil, false, err
+	}
+	defer it.Close()
+
+	var tags []*TagWithDate
+	dates := make(map[graveler.CommitID]time.Time)
+	for it.Next() {
+		v := it.Value()
+		date, ok := dates[v.CommitID]
+		if !ok {
+			commit, err := c.Store.GetCommit(ctx, repository, v.CommitID)
+			if err != nil {
+				return nil, false, fmt.Errorf("tag %s: %w", v.TagID, err)
+			}
+			date = commit.CreationDate
+			dates[v.CommitID] = date
+		}
+		tags = append(tags, &TagWithDate{
+			Tag:          Tag{ID: v.TagID.String(), CommitID: v.CommitID.String()},
+			CreationDate: date,
+		})
+	}
+	if err := it.Err(); err != nil {
+		return nil, false, err
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		if !tags[i].CreationDate.Equal(tags[j].CreationDate) {
+			return tags[i].CreationDate.After(tags[j].CreationDate)
+		}
+		return tags[i].ID < tags[j].ID
+	})
+
+	start := 0
+	if afterID != "" {
+		start = sort.Search(len(tags), func(i int) bool {
+			if !tags[i].CreationDate.Equal(afterDate) {
+				return tags[i].CreationDate.Before(afterDate)
+			}
+			return tags[i].ID > afterID
+		})
+	}
+	tags = tags[start:]
+	hasMore := false
+	if len(tags) > limit {
+		hasMore = true
+		tags = tags[:limit]
+	}
+	return tags, hasMore, nil
+}
+
+func (c *Catalog) GetTag(ctx context.Context, repositoryID string, tagID string) (string, error) {
+	tag := graveler.TagID(tagID)
+	if err := validator.Validate([]validator.ValidateArg{
+		{Name: "name", Value: repositoryID, Fn: graveler.ValidateRepositoryID},
+		{Name: "tagID", Value: tag, Fn: graveler.ValidateTagID},
+	}); err != nil {
+		return "", err
 	}
 	repository, err := c.getRepository(ctx, repositoryID)
 	if err != nil {
@@ -999,6 +1505,381 @@ func (c *Catalog) GetEntry(ctx context.Context, repositoryID string, reference s
 	return &catalogEntry, nil
 }
 
+// GetEntryOrPrefix returns the entry at path on reference, or, when there is none, a common level entry for path as a
+// prefix if reference has entries under it. The prefix is path ending with delimiter. It returns ErrNotFound when
+// neither exists, so callers that don't know whether path is an object or a "directory" need a single call.
+func (c *Catalog) GetEntryOrPrefix(ctx context.Context, repositoryID, reference, path, delimiter string) (*DBEntry, error) {
+	entry, err := c.GetEntry(ctx, repositoryID, reference, path, GetEntryParams{})
+	if !errors.Is(err, graveler.ErrNotFound) {
+		return entry, err
+	}
+	prefix := path
+	if delimiter != "" && !strings.HasSuffix(prefix, delimiter) {
+		prefix += delimiter
+	}
+	exist, err := c.PrefixesExist(ctx, repositoryID, reference, []string{prefix})
+	if err != nil {
+		return nil, err
+	}
+	if !exist[prefix] {
+		return nil, graveler.ErrNotFound
+	}
+	return &DBEntry{CommonLevel: true, Path: prefix}, nil
+}
+
+// GetEntryAtCommit returns the entry of path as committed in commitID. Unlike GetEntry, it accepts only a full
+// commit ID and never reads staged data, so it cannot be pointed at a branch by mistake.
+func (c *Catalog) GetEntryAtCommit(ctx context.Context, repositoryID string, commitID string, path string) (*DBEntry, error) {
+	id := graveler.CommitID(commitID)
+	if err := validator.Validate([]validator.ValidateArg{
+		{Name: "repository", Value: repositoryID, Fn: graveler.ValidateRepositoryID},
+		{Name: "commit", Value: id, Fn: graveler.ValidateCommitID},
+		{Name: "path", Value: Path(path), Fn: ValidatePath},
+	}); err != nil {
+		return nil, err
+	}
+	repository, err := c.getRepository(ctx, repositoryID)
+	if err != nil {
+		return nil, err
+	}
+	val, err := c.Store.GetByCommitID(ctx, repository, id, graveler.Key(path))
+	if err != nil {
+		return nil, err
+	}
+	ent, err := ValueToEntry(val)
+	if err != nil {
+		return nil, err
+	}
+	catalogEntry := newCatalogEntryFromEntry(false, path, ent)
+	return &catalogEntry, nil
+}
+
+// GetEntrySize returns the size of the object at path, without building the rest of its entry.
+func (c *Catalog) GetEntrySize(ctx context.Context, repositoryID string, reference string, path string, params GetEntryParams) (int64, error) {
+	refToGet := graveler.Ref(reference)
+	if err := validator.Validate([]validator.ValidateArg{
+		{Name: "repository", Value: repositoryID, Fn: graveler.ValidateRepositoryID},
+		{Name: "ref", Value: refToGet, Fn: graveler.ValidateRef},
+		{Name: "path", Value: Path(path), Fn: ValidatePath},
+	}); err != nil {
+		return 0, err
+	}
+	repository, err := c.getRepository(ctx, repositoryID)
+	if err != nil {
+		return 0, err
+	}
+	val, err := c.Store.Get(ctx, repository, refToGet, graveler.Key(path), graveler.WithStageOnly(params.StageOnly))
+	if err != nil {
+		return 0, err
+	}
+	return ValueToEntrySize(val)
+}
+
+// GetEntries returns the entries of paths on reference, keyed by path, up to GetEntriesMaxSize paths. Paths that do
+// not exist are omitted from the result. reference is resolved once, so all paths are read from the same commit and
+// staging area. Each entry is read as GetEntry reads it, so uncommitted reads on a branch behave the same.
+func (c *Catalog) GetEntries(ctx context.Context, repositoryID string, reference string, paths []string, params GetEntryParams) (map[string]*DBEntry, error) {
+	refToGet := graveler.Ref(reference)
+	if err := validator.Validate([]validator.ValidateArg{
+		{Name: "repository", Value: repositoryID, Fn: graveler.ValidateRepositoryID},
+		{Name: "ref", Value: refToGet, Fn: graveler.ValidateRef},
+	}); err != nil {
+		return nil, err
+	}
+	if len(paths) > GetEntriesMaxSize {
+		return nil, fmt.Errorf("paths length (%d) passed the maximum allowed(%d): %w", len(paths), GetEntriesMaxSize, graveler.ErrInvalidValue)
+	}
+	for i, path := range paths {
+		if err := ValidatePath(Path(path)); err != nil {
+			return nil, fmt.Errorf("argument path[%d]: %w", i, err)
+		}
+	}
+	repository, err := c.getRepository(ctx, repositoryID)
+	if err != nil {
+		return nil, err
+	}
+	resolvedRef, err := c.Store.Dereference(ctx, repository, refToGet)
+	if err != nil {
+		return nil, err
+	}
+	entries := make(map[string]*DBEntry, len(paths))
+	for _, path := range paths {
+		if _, ok := entries[path]; ok {
+			continue
+		}
+		val, err := c.Store.GetByResolvedRef(ctx, repository, resolvedRef, graveler.Key(path), graveler.WithStageOnly(params.StageOnly))
+		if errors.Is(err, graveler.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("path %s: %w", path, err)
+		}
+		ent, err := ValueToEntry(val)
+		if err != nil {
+			return nil, fmt.Errorf("path %s: %w", path, err)
+		}
+		catalogEntry := newCatalogEntryFromEntry(false, path, ent)
+		entries[path] = &catalogEntry
+	}
+	return entries, nil
+}
+
+// ScanEntriesParallel scans the entries under prefix in reference using up to shards independent scans, each
+// delivering its entries on its own channel. A shard ends with an EntryOrError holding an error if its scan failed,
+// and its channel is closed when it is done. Consumers must drain all channels or cancel ctx. reference is resolved
+// once, so all shards scan the same commit and staging area.
+//
+// Shards are contiguous key ranges, split on the first-level children of prefix (using DefaultPathDelimiter): the
+// children are divided evenly between the shards, so shards are balanced by the number of children and not by the
+// number of entries. Only the first ScanShardsMaxChildren children are used to choose boundaries, the last shard
+// covers all keys after them. Entries are sorted within each shard, and shards are returned in key order.
+func (c *Catalog) ScanEntriesParallel(ctx context.Context, repositoryID, reference, prefix string, shards int) ([]<-chan EntryOrError, error) {
+	ref := graveler.Ref(reference)
+	if err := validator.Validate([]validator.ValidateArg{
+		{Name: "repository", Value: repositoryID, Fn: graveler.ValidateRepositoryID},
+		{Name: "ref", Value: ref, Fn: graveler.ValidateRef},
+		{Name: "prefix", Value: Path(prefix), Fn: ValidatePathOptional},
+	}); err != nil {
+		return nil, err
+	}
+	if shards < 1 || shards > ScanShardsMax {
+		return nil, fmt.Errorf("shards %d not in range 1-%d: %w", shards, ScanShardsMax, graveler.ErrInvalidValue)
+	}
+	repository, err := c.getRepository(ctx, repositoryID)
+	if err != nil {
+		return nil, err
+	}
+	// resolve the reference once, so all shards scan the same commit and staging area
+	resolvedRef, err := c.Store.Dereference(ctx, repository, ref)
+	if err != nil {
+		return nil, err
+	}
+	children, err := c.listChildren(ctx, repository, resolvedRef, prefix, ScanShardsMaxChildren)
+	if err != nil {
+		return nil, err
+	}
+
+	// boundaries holds the first key of each shard after the first
+	var boundaries []graveler.Key
+	shards = min(shards, len(children))
+	for i := 1; i < shards; i++ {
+		boundaries = append(boundaries, graveler.Key(children[i*len(children)/shards]))
+	}
+	// open all iterators before starting any shard, so a failure leaves nothing running
+	iterators := make([]graveler.ValueIterator, 0, len(boundaries)+1)
+	for i := 0; i <= len(boundaries); i++ {
+		it, err := c.Store.ListByResolvedRef(ctx, repository, resolvedRef, ListEntriesLimitMax)
+		if err != nil {
+			for _, it := range iterators {
+				it.Close()
+			}
+			return nil, err
+		}
+		iterators = append(iterators, it)
+	}
+
+	const shardBufferSize = 1000
+	start := graveler.Key(prefix)
+	results := make([]<-chan EntryOrError, 0, len(iterators))
+	for i, it := range iterators {
+		var end graveler.Key
+		if i < len(boundaries) {
+			end = boundaries[i]
+		}
+		ch := make(chan EntryOrError, shardBufferSize)
+		go scanShard(ctx, it, prefix, start, end, ch)
+		results = append(results, ch)
+		start = end
+	}
+	return results, nil
+}
+
+// listChildren returns up to limit first-level children of prefix in reference, in key order
+func (c *Catalog) listChildren(ctx context.Context, repository *graveler.RepositoryRecord, reference *graveler.ResolvedRef, prefix string, limit int) ([]string, error) {
+	iter, err := c.Store.ListByResolvedRef(ctx, repository, reference, ListEntriesLimitMax)
+	if err != nil {
+		return nil, err
+	}
+	it := NewEntryListingIterator(NewValueToEntryIterator(iter), Path(prefix), DefaultPathDelimiter)
+	defer it.Close()
+	var children []string
+	for len(children) < limit && it.Next() {
+		children = append(children, it.Value().Path.String())
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	return children, nil
+}
+
+// scanShard sends the entries of it with prefix, from start up to end (or to the end of prefix if end is nil), to ch
+// and closes ch when done
+func scanShard(ctx context.Context, it graveler.ValueIterator, prefix string, start, end graveler.Key, ch chan<- EntryOrError) {
+	defer close(ch)
+	defer it.Close()
+	send := func(result EntryOrError) bool {
+		select {
+		case ch <- result:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+	it.SeekGE(start)
+	for it.Next() {
+		record := it.Value()
+		if !strings.HasPrefix(record.Key.String(), prefix) || (end != nil && bytes.Compare(record.Key, end) >= 0) {
+			break
+		}
+		ent, err := ValueToEntry(record.Value)
+		if err != nil {
+			send(EntryOrError{Err: err})
+			return
+		}
+		entry := newCatalogEntryFromEntry(false, record.Key.String(), ent)
+		if !send(EntryOrError{Entry: &entry}) {
+			return
+		}
+	}
+	if err := it.Err(); err != nil {
+		send(EntryOrError{Err: err})
+	}
+}
+
+// TreeShapeStats returns the number of entries, the maximal depth and the maximal number of direct children of a
+// directory under prefix in reference. Depth and directories are relative to prefix.
+// At most TreeShapeMaxEntries entries are read: on larger trees the stats describe only the first entries in path
+// order, and the result is marked as Sampled.
+func (c *Catalog) TreeShapeStats(ctx context.Context, repositoryID, reference, prefix string) (*TreeShape, error) {
+	ref := graveler.Ref(reference)
+	if err := validator.Validate([]validator.ValidateArg{
+		{Name: "repository", Value: repositoryID, Fn: graveler.ValidateRepositoryID},
+		{Name: "ref", Value: ref, Fn: graveler.ValidateRef},
+		{Name: "prefix", Value: Path(prefix), Fn: ValidatePathOptional},
+	}); err != nil {
+		return nil, err
+	}
+	repository, err := c.getRepository(ctx, repositoryID)
+	if err != nil {
+		return nil, err
+	}
+	it, err := c.Store.List(ctx, repository, ref, ListEntriesLimitMax)
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+	it.SeekGE(graveler.Key(prefix))
+
+	// paths are sorted, so all the paths of a directory are adjacent and counting child name changes per
+	// directory counts its distinct children
+	fanOut := make(map[string]int)
+	lastChild := make(map[string]string)
+	shape := &TreeShape{}
+	for it.Next() {
+		key := it.Value().Key.String()
+		if !strings.HasPrefix(key, prefix) {
+			break
+		}
+		if shape.EntryCount >= TreeShapeMaxEntries {
+			shape.Sampled = true
+			break
+		}
+		shape.EntryCount++
+		parts := strings.Split(strings.TrimPrefix(key, prefix), DefaultPathDelimiter)
+		shape.MaxDepth = max(shape.MaxDepth, len(parts))
+		dir := prefix
+		for _, part := range parts {
+			if child, ok := lastChild[dir]; !ok || child != part {
+				lastChild[dir] = part
+				fanOut[dir]++
+				if fanOut[dir] > shape.MaxFanOut {
+					shape.MaxFanOut = fanOut[dir]
+					shape.MaxFanOutPrefix = dir
+				}
+			}
+			dir += part + DefaultPathDelimiter
+		}
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	return shape, nil
+}
+
+// EntryMatchesETag reports whether the entry of path under reference has the given ETag.
+// Quotes around either ETag are ignored, so a value taken from an If-None-Match header can be passed as is.
+func (c *Catalog) EntryMatchesETag(ctx context.Context, repositoryID string, reference string, path string, etag string) (bool, error) {
+	entry, err := c.GetEntry(ctx, repositoryID, reference, path, GetEntryParams{})
+	if err != nil {
+		return false, err
+	}
+	return httputil.StripQuotesAndSpaces(entry.Checksum) == httputil.StripQuotesAndSpaces(etag), nil
+}
+
+// WasDeleted reports whether path existed on branch at some point and was removed by a commit.
+// It returns the deleting commit when found. The walk follows first-parent history and looks
+// at WasDeletedMaxCommits commits at most, reporting false if the deletion is older than that.
+func (c *Catalog) WasDeleted(ctx context.Context, repositoryID string, branch string, path string) (bool, *CommitLog, error) {
+	branchID := graveler.BranchID(branch)
+	if err := validator.Validate([]validator.ValidateArg{
+		{Name: "repository", Value: repositoryID, Fn: graveler.ValidateRepositoryID},
+		{Name: "branch", Value: branchID, Fn: graveler.ValidateBranchID},
+		{Name: "path", Value: Path(path), Fn: ValidatePath},
+	}); err != nil {
+		return false, nil, err
+	}
+	repository, err := c.getRepository(ctx, repositoryID)
+	if err != nil {
+		return false, nil, err
+	}
+	key := graveler.Key(path)
+
+	// path is currently visible on the branch - nothing was deleted
+	_, err = c.Store.Get(ctx, repository, graveler.Ref(branchID), key)
+	if err == nil {
+		return false, nil, nil
+	}
+	if !errors.Is(err, graveler.ErrNotFound) {
+		return false, nil, err
+	}
+
+	b, err := c.Store.GetBranch(ctx, repository, branchID)
+	if err != nil {
+		return false, nil, err
+	}
+	// path exists on the branch head commit, and it is only deleted on staging
+	_, err = c.Store.GetByCommitID(ctx, repository, b.CommitID, key)
+	if err == nil {
+		return false, nil, nil
+	}
+	if !errors.Is(err, graveler.ErrNotFound) {
+		return false, nil, err
+	}
+
+	it, err := c.Store.Log(ctx, repository, b.CommitID, true, nil)
+	if err != nil {
+		return false, nil, err
+	}
+	defer it.Close()
+	for i := 0; i < WasDeletedMaxCommits && it.Next(); i++ {
+		commit := it.Value()
+		if len(commit.Parents) == 0 {
+			break
+		}
+		_, err := c.Store.GetByCommitID(ctx, repository, commit.Parents[0], key)
+		if err == nil {
+			return true, CommitRecordToLog(commit), nil
+		}
+		if !errors.Is(err, graveler.ErrNotFound) {
+			return false, nil, err
+		}
+	}
+	if err := it.Err(); err != nil {
+		return false, nil, err
+	}
+	return false, nil, nil
+}
+
 func newEntryFromCatalogEntry(entry DBEntry) *Entry {
 	ent := &Entry{
 		Address:      entry.PhysicalAddress,
@@ -1058,25 +1939,62 @@ func (c *Catalog) CreateEntry(ctx context.Context, repositoryID string, branch s
 	if err != nil {
 		return err
 	}
+	if err := c.checkMaintenance(repository); err != nil {
+		return err
+	}
 	return c.Store.Set(ctx, repository, branchID, key, *value, opts...)
 }
 
-func (c *Catalog) DeleteEntry(ctx context.Context, repositoryID string, branch string, path string, opts ...graveler.SetOptionsFunc) error {
+// CreateEntries stores a batch of entries on branch in a single staging write. All entries are validated before
+// any of them is written.
+func (c *Catalog) CreateEntries(ctx context.Context, repositoryID string, branch string, entries []DBEntry, opts ...graveler.SetOptionsFunc) error {
 	branchID := graveler.BranchID(branch)
-	p := Path(path)
 	if err := validator.Validate([]validator.ValidateArg{
 		{Name: "repository", Value: repositoryID, Fn: graveler.ValidateRepositoryID},
 		{Name: "branch", Value: branchID, Fn: graveler.ValidateBranchID},
-		{Name: "path", Value: p, Fn: ValidatePath},
 	}); err != nil {
 		return err
 	}
+	records := make([]graveler.ValueRecord, len(entries))
+	for i, entry := range entries {
+		if err := ValidatePath(Path(entry.Path)); err != nil {
+			return fmt.Errorf("argument path[%d]: %w", i, err)
+		}
+		value, err := EntryToValue(newEntryFromCatalogEntry(entry))
+		if err != nil {
+			return fmt.Errorf("entry %s: %w", entry.Path, err)
+		}
+		records[i] = graveler.ValueRecord{Key: graveler.Key(entry.Path), Value: value}
+	}
 	repository, err := c.getRepository(ctx, repositoryID)
 	if err != nil {
 		return err
 	}
-	key := graveler.Key(p)
-	return c.Store.Delete(ctx, repository, branchID, key, opts...)
+	if err := c.checkMaintenance(repository); err != nil {
+		return err
+	}
+	return c.Store.SetBatch(ctx, repository, branchID, records, opts...)
+}
+
+func (c *Catalog) DeleteEntry(ctx context.Context, repositoryID string, branch string, path string, opts ...graveler.SetOptionsFunc) error {
+	branchID := graveler.BranchID(branch)
+	p := Path(path)
+	if err := validator.Validate([]validator.ValidateArg{
+		{Name: "repository", Value: repositoryID, Fn: graveler.ValidateRepositoryID},
+		{Name: "branch", Value: branchID, Fn: graveler.ValidateBranchID},
+		{Name: "path", Value: p, Fn: ValidatePath},
+	}); err != nil {
+		return err
+	}
+	repository, err := c.getRepository(ctx, repositoryID)
+	if err != nil {
+		return err
+	}
+	if err := c.checkMaintenance(repository); err != nil {
+		return err
+	}
+	key := graveler.Key(p)
+	return c.Store.Delete(ctx, repository, branchID, key, opts...)
 }
 
 func (c *Catalog) DeleteEntries(ctx context.Context, repositoryID string, branch string, paths []string, opts ...graveler.SetOptionsFunc) error {
@@ -1099,6 +2017,9 @@ func (c *Catalog) DeleteEntries(ctx context.Context, repositoryID string, branch
 	if err != nil {
 		return err
 	}
+	if err := c.checkMaintenance(repository); err != nil {
+		return err
+	}
 
 	keys := make([]graveler.Key, len(paths))
 	for i := range paths {
@@ -1107,6 +2028,77 @@ func (c *Catalog) DeleteEntries(ctx context.Context, repositoryID string, branch
 	return c.Store.DeleteBatch(ctx, repository, branchID, keys, opts...)
 }
 
+// DeleteEntriesReportMissing deletes paths from branch like DeleteEntries, and returns the paths that were not
+// found on the branch. Paths are checked before the delete, a path removed concurrently is deleted as usual.
+func (c *Catalog) DeleteEntriesReportMissing(ctx context.Context, repositoryID string, branch string, paths []string, opts ...graveler.SetOptionsFunc) ([]string, error) {
+	branchID := graveler.BranchID(branch)
+	if err := validator.Validate([]validator.ValidateArg{
+		{Name: "repository", Value: repositoryID, Fn: graveler.ValidateRepositoryID},
+		{Name: "branch", Value: branchID, Fn: graveler.ValidateBranchID},
+	}); err != nil {
+		return nil, err
+	}
+	for i, path := range paths {
+		if err := ValidatePath(Path(path)); err != nil {
+			return nil, fmt.Errorf("argument path[%d]: %w", i, err)
+		}
+	}
+	repository, err := c.getRepository(ctx, repositoryID)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.checkMaintenance(repository); err != nil {
+		return nil, err
+	}
+
+	var missing []string
+	existing := make([]string, 0, len(paths))
+	for _, path := range paths {
+		_, err := c.Store.Get(ctx, repository, branchID.Ref(), graveler.Key(path))
+		if errors.Is(err, graveler.ErrNotFound) {
+			missing = append(missing, path)
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("get %s: %w", path, err)
+		}
+		existing = append(existing, path)
+	}
+	if len(existing) == 0 {
+		return missing, nil
+	}
+	if err := c.DeleteEntries(ctx, repositoryID, branch, existing, opts...); err != nil {
+		return nil, err
+	}
+	return missing, nil
+}
+
+// NewEntryIterator returns an iterator over the entries of reference under prefix, grouped by delimiter when it is set.
+// Unlike ListEntries it is not paginated: values are read from the underlying store in batches as the iterator advances,
+// so large scans run with bounded memory. The caller must close the iterator.
+func (c *Catalog) NewEntryIterator(ctx context.Context, repositoryID, reference, prefix, delimiter string) (EntryListingIterator, error) {
+	prefixPath := Path(prefix)
+	delimiterPath := Path(delimiter)
+	refToList := graveler.Ref(reference)
+	if err := validator.Validate([]validator.ValidateArg{
+		{Name: "repository", Value: repositoryID, Fn: graveler.ValidateRepositoryID},
+		{Name: "ref", Value: refToList, Fn: graveler.ValidateRef},
+		{Name: "prefix", Value: prefixPath, Fn: ValidatePathOptional},
+		{Name: "delimiter", Value: delimiterPath, Fn: ValidatePathOptional},
+	}); err != nil {
+		return nil, err
+	}
+	repository, err := c.getRepository(ctx, repositoryID)
+	if err != nil {
+		return nil, err
+	}
+	iter, err := c.Store.List(ctx, repository, refToList, ListEntriesLimitMax)
+	if err != nil {
+		return nil, err
+	}
+	return NewEntryListingIterator(NewValueToEntryIterator(iter), prefixPath, delimiterPath), nil
+}
+
 func (c *Catalog) ListEntries(ctx context.Context, repositoryID string, reference string, prefix string, after string, delimiter string, limit int) ([]*DBEntry, bool, error) {
 	// normalize limit
 	if limit < 0 || limit > ListEntriesLimitMax {
@@ -1163,6 +2155,110 @@ func (c *Catalog) ListEntries(ctx context.Context, repositoryID string, referenc
 	return entries, hasMore, nil
 }
 
+// ListPhysicalAddresses returns the distinct storage addresses, as full URIs, of the objects in reference.
+// Addresses are returned in address order and paginated by address: pass the last address of a page as after to
+// get the next one. An address referenced by several paths is returned once across all pages.
+// Each page scans all entries of reference, keeping only the limit+1 smallest addresses following after in memory.
+func (c *Catalog) ListPhysicalAddresses(ctx context.Context, repositoryID, reference string, limit int, after string) ([]string, bool, error) {
+	if limit < 0 || limit > ListEntriesLimitMax {
+		limit = ListEntriesLimitMax
+	}
+	ref := graveler.Ref(reference)
+	if err := validator.Validate([]validator.ValidateArg{
+		{Name: "repository", Value: repositoryID, Fn: graveler.ValidateRepositoryID},
+		{Name: "ref", Value: ref, Fn: graveler.ValidateRef},
+	}); err != nil {
+		return nil, false, err
+	}
+	repository, err := c.getRepository(ctx, repositoryID)
+	if err != nil {
+		return nil, false, err
+	}
+	iter, err := c.Store.List(ctx, repository, ref, ListEntriesLimitMax)
+	if err != nil {
+		return nil, false, err
+	}
+	it := NewValueToEntryIterator(iter)
+	defer it.Close()
+
+	// keep the limit+1 smallest distinct addresses after the cursor, the extra one tells if there are more
+	var addresses addressHeap
+	kept := make(map[string]struct{})
+	for it.Next() {
+		v := it.Value()
+		qk, err := c.BlockAdapter.ResolveNamespace(repository.StorageNamespace.String(), v.Entry.Address, addressTypeToCatalog(v.Entry.AddressType).ToIdentifierType())
+		if err != nil {
+			return nil, false, fmt.Errorf("resolve address of %s: %w", v.Path, err)
+		}
+		address := qk.Format()
+		if address <= after {
+			continue
+		}
+		if _, ok := kept[address]; ok {
+			continue
+		}
+		if addresses.Len() > limit {
+			if address >= addresses[0] {
+				continue
+			}
+			delete(kept, heap.Pop(&addresses).(string))
+		}
+		heap.Push(&addresses, address)
+		kept[address] = struct{}{}
+	}
+	if err := it.Err(); err != nil {
+		return nil, false, err
+	}
+	sort.Strings(addresses)
+	hasMore := false
+	if len(addresses) > limit {
+		hasMore = true
+		addresses = addresses[:limit]
+	}
+	return addresses, hasMore, nil
+}
+
+// PrefixesExist reports for each prefix whether it has any entries under reference.
+// The reference is resolved once and a single listing is shared by all prefixes.
+func (c *Catalog) PrefixesExist(ctx context.Context, repositoryID string, reference string, prefixes []string) (map[string]bool, error) {
+	ref := graveler.Ref(reference)
+	if err := validator.Validate([]validator.ValidateArg{
+		{Name: "repository", Value: repositoryID, Fn: graveler.ValidateRepositoryID},
+		{Name: "ref", Value: ref, Fn: graveler.ValidateRef},
+	}); err != nil {
+		return nil, err
+	}
+	for _, prefix := range prefixes {
+		if err := ValidatePathOptional(Path(prefix)); err != nil {
+			return nil, fmt.Errorf("prefix %s: %w", prefix, err)
+		}
+	}
+	repository, err := c.getRepository(ctx, repositoryID)
+	if err != nil {
+		return nil, err
+	}
+	// a single value is needed after each seek
+	it, err := c.Store.List(ctx, repository, ref, 1)
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+
+	exist := make(map[string]bool, len(prefixes))
+	for _, prefix := range prefixes {
+		it.SeekGE(graveler.Key(prefix))
+		if it.Next() {
+			exist[prefix] = strings.HasPrefix(string(it.Value().Key), prefix)
+		} else {
+			exist[prefix] = false
+		}
+		if err := it.Err(); err != nil {
+			return nil, err
+		}
+	}
+	return exist, nil
+}
+
 func (c *Catalog) ResetEntry(ctx context.Context, repositoryID string, branch string, path string, opts ...graveler.SetOptionsFunc) error {
 	branchID := graveler.BranchID(branch)
 	entryPath := Path(path)
@@ -1177,6 +2273,9 @@ func (c *Catalog) ResetEntry(ctx context.Context, repositoryID string, branch st
 	if err != nil {
 		return err
 	}
+	if err := c.checkMaintenance(repository); err != nil {
+		return err
+	}
 	key := graveler.Key(entryPath)
 	return c.Store.ResetKey(ctx, repository, branchID, key, opts...)
 }
@@ -1194,6 +2293,9 @@ func (c *Catalog) ResetEntries(ctx context.Context, repositoryID string, branch
 	if err != nil {
 		return err
 	}
+	if err := c.checkMaintenance(repository); err != nil {
+		return err
+	}
 	keyPrefix := graveler.Key(prefixPath)
 	return c.Store.ResetPrefix(ctx, repository, branchID, keyPrefix, opts...)
 }
@@ -1211,6 +2313,9 @@ func (c *Catalog) Commit(ctx context.Context, repositoryID, branch, message, com
 	if err != nil {
 		return nil, err
 	}
+	if err := c.checkMaintenance(repository); err != nil {
+		return nil, err
+	}
 
 	p := graveler.CommitParams{
 		Committer:  committer,
@@ -1248,11 +2353,100 @@ func (c *Catalog) Commit(ctx context.Context, repositoryID, branch, message, com
 	return catalogCommitLog, nil
 }
 
+// CommitWithParents commits the branch content like Commit, but with the given commits as parents instead of the
+// branch head. It is meant for tools that rewrite history, such as rebase. The parents must exist.
+func (c *Catalog) CommitWithParents(ctx context.Context, repositoryID, branch, message, committer string, parents []string, metadata Metadata, opts ...graveler.SetOptionsFunc) (*CommitLog, error) {
+	branchID := graveler.BranchID(branch)
+	if err := validator.Validate([]validator.ValidateArg{
+		{Name: "repository", Value: repositoryID, Fn: graveler.ValidateRepositoryID},
+		{Name: "branch", Value: branchID, Fn: graveler.ValidateBranchID},
+	}); err != nil {
+		return nil, err
+	}
+	if len(parents) == 0 {
+		return nil, fmt.Errorf("parents: %w", graveler.ErrRequiredValue)
+	}
+	commitParents := make(graveler.CommitParents, len(parents))
+	for i, parent := range parents {
+		if err := graveler.ValidateCommitID(graveler.CommitID(parent)); err != nil {
+			return nil, fmt.Errorf("argument parents[%d]: %w", i, err)
+		}
+		commitParents[i] = graveler.CommitID(parent)
+	}
+
+	repository, err := c.getRepository(ctx, repositoryID)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.checkMaintenance(repository); err != nil {
+		return nil, err
+	}
+
+	commitID, err := c.Store.Commit(ctx, repository, branchID, graveler.CommitParams{
+		Committer: committer,
+		Message:   message,
+		Metadata:  map[string]string(metadata),
+		Parents:   commitParents,
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	commit, err := c.Store.GetCommit(ctx, repository, commitID)
+	if err != nil {
+		return nil, err
+	}
+	return CommitRecordToLog(&graveler.CommitRecord{CommitID: commitID, Commit: commit}), nil
+}
+
+// CommitPaths commits only the staged changes of the given paths, other staged changes are left uncommitted.
+// Paths without staged changes are ignored.
+func (c *Catalog) CommitPaths(ctx context.Context, repositoryID, branch string, paths []string, message, committer string, metadata Metadata, opts ...graveler.SetOptionsFunc) (*CommitLog, error) {
+	branchID := graveler.BranchID(branch)
+	if err := validator.Validate([]validator.ValidateArg{
+		{Name: "repository", Value: repositoryID, Fn: graveler.ValidateRepositoryID},
+		{Name: "branch", Value: branchID, Fn: graveler.ValidateBranchID},
+	}); err != nil {
+		return nil, err
+	}
+	keys := make([]graveler.Key, len(paths))
+	for i, path := range paths {
+		if err := ValidatePath(Path(path)); err != nil {
+			return nil, fmt.Errorf("argument path[%d]: %w", i, err)
+		}
+		keys[i] = graveler.Key(path)
+	}
+
+	repository, err := c.getRepository(ctx, repositoryID)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.checkMaintenance(repository); err != nil {
+		return nil, err
+	}
+
+	commitID, err := c.Store.CommitKeys(ctx, repository, branchID, keys, graveler.CommitParams{
+		Committer: committer,
+		Message:   message,
+		Metadata:  map[string]string(metadata),
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	commit, err := c.Store.GetCommit(ctx, repository, commitID)
+	if err != nil {
+		return nil, err
+	}
+	return CommitRecordToLog(&graveler.CommitRecord{CommitID: commitID, Commit: commit}), nil
+}
+
 func (c *Catalog) CreateCommitRecord(ctx context.Context, repositoryID string, commitID string, version int, committer string, message string, metaRangeID string, creationDate int64, parents []string, metadata map[string]string, generation int, opts ...graveler.SetOptionsFunc) error {
 	repository, err := c.getRepository(ctx, repositoryID)
 	if err != nil {
 		return err
 	}
+	if err := c.checkMaintenance(repository); err != nil {
+		return err
+	}
 	commitParents := make([]graveler.CommitID, len(parents))
 	for i, parent := range parents {
 		commitParents[i] = graveler.CommitID(parent)
@@ -1353,32 +2547,515 @@ func (c *Catalog) ListCommits(ctx context.Context, repositoryID string, branch s
 		}
 	}
 
+	if params.hasCommitFilters() {
+		it = &commitFilterIterator{CommitIterator: it, params: params}
+	}
 	paths := params.PathList
 	if len(paths) == 0 {
 		return listCommitsWithoutPaths(it, params)
 	}
-
 	return c.listCommitsWithPaths(ctx, repository, it, params)
 }
 
-func (c *Catalog) listCommitsWithPaths(ctx context.Context, repository *graveler.RepositoryRecord, it graveler.CommitIterator, params LogParams) ([]*CommitLog, bool, error) {
-	// verify we are not listing commits without any paths
-	if len(params.PathList) == 0 {
-		return nil, false, fmt.Errorf("%w: list commits without paths", graveler.ErrInvalid)
+// LastMergeInto returns the most recent merge commit on branch, following first parents, and the commit ID that was
+// merged by it. It fails with graveler.ErrNotFound if nothing was merged into branch.
+func (c *Catalog) LastMergeInto(ctx context.Context, repositoryID, branch string) (*CommitLog, string, error) {
+	branchID := graveler.BranchID(branch)
+	if err := validator.Validate([]validator.ValidateArg{
+		{Name: "repository", Value: repositoryID, Fn: graveler.ValidateRepositoryID},
+		{Name: "branch", Value: branchID, Fn: graveler.ValidateBranchID},
+	}); err != nil {
+		return nil, "", err
+	}
+	repository, err := c.getRepository(ctx, repositoryID)
+	if err != nil {
+		return nil, "", err
+	}
+	b, err := c.Store.GetBranch(ctx, repository, branchID)
+	if err != nil {
+		return nil, "", err
+	}
+	it, err := c.Store.Log(ctx, repository, b.CommitID, true, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	defer it.Close()
+	for it.Next() {
+		commit := it.Value()
+		if len(commit.Parents) > NumberOfParentsOfNonMergeCommit {
+			return CommitRecordToLog(commit), commit.Parents[1].String(), nil
+		}
+	}
+	if err := it.Err(); err != nil {
+		return nil, "", err
 	}
+	return nil, "", fmt.Errorf("merge into %s: %w", branch, graveler.ErrNotFound)
+}
 
-	// commit/key to value cache - helps when fetching the same commit/key while processing parent commits
-	const commitLogCacheSize = 1024 * 5
-	commitCache, err := lru.New(commitLogCacheSize)
+// ListEmptyCommits returns up to limit commits reachable from reference, following first parents, whose content is
+// identical to their first parent. Commits are compared by their metarange ID only, without a diff.
+func (c *Catalog) ListEmptyCommits(ctx context.Context, repositoryID string, reference string, limit int) ([]*CommitLog, error) {
+	if limit < 0 || limit > ListEmptyCommitsLimitMax {
+		limit = ListEmptyCommitsLimitMax
+	}
+	ref := graveler.Ref(reference)
+	if err := validator.Validate([]validator.ValidateArg{
+		{Name: "repository", Value: repositoryID, Fn: graveler.ValidateRepositoryID},
+		{Name: "ref", Value: ref, Fn: graveler.ValidateRef},
+	}); err != nil {
+		return nil, err
+	}
+	repository, err := c.getRepository(ctx, repositoryID)
 	if err != nil {
-		return nil, false, err
+		return nil, err
+	}
+	commitID, err := c.dereferenceCommitID(ctx, repository, ref)
+	if err != nil {
+		return nil, err
 	}
+	it, err := c.Store.Log(ctx, repository, commitID, true, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
 
-	const numReadResults = 3
-	done := atomic.NewBool(false)
+	// first parent log - each commit is the first parent of the one before it
+	var (
+		commits []*CommitLog
+		prev    *graveler.CommitRecord
+	)
+	for len(commits) < limit && it.Next() {
+		commit := it.Value()
+		if prev != nil && prev.MetaRangeID == commit.MetaRangeID {
+			commits = append(commits, CommitRecordToLog(prev))
+		}
+		prev = commit
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	return commits, nil
+}
 
-	// Shared workPool for the workers. 2 designated to create the work and receive the result
-	paths := params.PathList
+// CommitsBetweenTags returns the commits reachable from toTag, up to fromTag and not including it, in log order.
+// Results are paginated by limit and after, the last commit ID of the previous page.
+// It fails with ErrNotAncestor when fromTag is not reachable from toTag, and with ErrHistoryTooLong when a page is not
+// complete after walking TagsRangeMaxCommits commits.
+func (c *Catalog) CommitsBetweenTags(ctx context.Context, repositoryID, fromTag, toTag string, limit int, after string) ([]*CommitLog, bool, error) {
+	if limit < 0 || limit > ListTagsLimitMax {
+		limit = ListTagsLimitMax
+	}
+	fromTagID := graveler.TagID(fromTag)
+	toTagID := graveler.TagID(toTag)
+	if err := validator.Validate([]validator.ValidateArg{
+		{Name: "repository", Value: repositoryID, Fn: graveler.ValidateRepositoryID},
+		{Name: "from", Value: fromTagID, Fn: graveler.ValidateTagID},
+		{Name: "to", Value: toTagID, Fn: graveler.ValidateTagID},
+	}); err != nil {
+		return nil, false, err
+	}
+	repository, err := c.getRepository(ctx, repositoryID)
+	if err != nil {
+		return nil, false, err
+	}
+	fromCommitID, err := c.Store.GetTag(ctx, repository, fromTagID)
+	if err != nil {
+		return nil, false, fmt.Errorf("from tag %s: %w", fromTag, err)
+	}
+	toCommitID, err := c.Store.GetTag(ctx, repository, toTagID)
+	if err != nil {
+		return nil, false, fmt.Errorf("to tag %s: %w", toTag, err)
+	}
+
+	ancestor, err := c.Store.IsAncestor(ctx, repository, fromCommitID.Ref(), toCommitID.Ref())
+	if err != nil {
+		return nil, false, err
+	}
+	if !ancestor {
+		return nil, false, fmt.Errorf("tag %s of %s: %w", fromTag, toTag, ErrNotAncestor)
+	}
+
+	it, err := c.Store.Log(ctx, repository, *toCommitID, false, nil)
+	if err != nil {
+		return nil, false, err
+	}
+	defer it.Close()
+
+	afterCommitID := graveler.CommitID(after)
+	skip := after != ""
+	var commits []*CommitLog
+	for walked := 0; it.Next(); walked++ {
+		if walked >= TagsRangeMaxCommits {
+			return nil, false, fmt.Errorf("tag %s of %s: %w", fromTag, toTag, ErrHistoryTooLong)
+		}
+		commit := it.Value()
+		if commit.CommitID == *fromCommitID {
+			break
+		}
+		if skip {
+			skip = commit.CommitID != afterCommitID
+			continue
+		}
+		commits = append(commits, CommitRecordToLog(commit))
+		if len(commits) > limit {
+			break
+		}
+	}
+	if err := it.Err(); err != nil {
+		return nil, false, err
+	}
+	hasMore := false
+	if len(commits) > limit {
+		hasMore = true
+		commits = commits[:limit]
+	}
+	return commits, hasMore, nil
+}
+
+// PathDiffRange returns the commits reachable from toReference and not from fromReference that changed the physical
+// address of path, most recent first. Each item holds the path entry before and after the commit.
+// History is walked once, following the first parent of each commit, for ProvenanceMaxCommits commits at most.
+// It fails with ErrNotAncestor when fromReference is not a first-parent ancestor of toReference, and with
+// ErrHistoryTooLong when fromReference was not reached within ProvenanceMaxCommits commits.
+func (c *Catalog) PathDiffRange(ctx context.Context, repositoryID, path, fromReference, toReference string) ([]*PathVersionDiff, error) {
+	if err := validator.Validate([]validator.ValidateArg{
+		{Name: "repository", Value: repositoryID, Fn: graveler.ValidateRepositoryID},
+		{Name: "path", Value: Path(path), Fn: ValidatePath},
+		{Name: "from", Value: graveler.Ref(fromReference), Fn: graveler.ValidateRef},
+		{Name: "to", Value: graveler.Ref(toReference), Fn: graveler.ValidateRef},
+	}); err != nil {
+		return nil, err
+	}
+	repository, err := c.getRepository(ctx, repositoryID)
+	if err != nil {
+		return nil, err
+	}
+	fromCommitID, err := c.dereferenceCommitID(ctx, repository, graveler.Ref(fromReference))
+	if err != nil {
+		return nil, fmt.Errorf("from ref: %w", err)
+	}
+	toCommitID, err := c.dereferenceCommitID(ctx, repository, graveler.Ref(toReference))
+	if err != nil {
+		return nil, fmt.Errorf("to ref: %w", err)
+	}
+
+	key := graveler.Key(path)
+	after, err := c.getCommittedEntry(ctx, repository, toCommitID, key)
+	if err != nil {
+		return nil, err
+	}
+	var diffs []*PathVersionDiff
+	end, err := c.walkPathVersions(ctx, repository, toCommitID, fromCommitID, key, after, func(diff *PathVersionDiff) bool {
+		diffs = append(diffs, diff)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	switch end {
+	case walkEndHistory:
+		return nil, fmt.Errorf("from ref %s: %w", fromReference, ErrNotAncestor)
+	case walkEndLimit:
+		return nil, fmt.Errorf("from ref %s not reached in %d commits: %w", fromReference, ProvenanceMaxCommits, ErrHistoryTooLong)
+	}
+	return diffs, nil
+}
+
+// ObjectProvenance returns the commit that introduced the committed version of path in reference, and the number
+// of versions of path before it. History is walked following first parents, for ProvenanceMaxCommits commits at
+// most: when the walk stops early, Commit is nil if it was not reached and PriorVersions counts only the versions seen.
+func (c *Catalog) ObjectProvenance(ctx context.Context, repositoryID, reference, path string) (*Provenance, error) {
+	if err := validator.Validate([]validator.ValidateArg{
+		{Name: "repository", Value: repositoryID, Fn: graveler.ValidateRepositoryID},
+		{Name: "ref", Value: graveler.Ref(reference), Fn: graveler.ValidateRef},
+		{Name: "path", Value: Path(path), Fn: ValidatePath},
+	}); err != nil {
+		return nil, err
+	}
+	repository, err := c.getRepository(ctx, repositoryID)
+	if err != nil {
+		return nil, err
+	}
+	commitID, err := c.dereferenceCommitID(ctx, repository, graveler.Ref(reference))
+	if err != nil {
+		return nil, err
+	}
+	key := graveler.Key(path)
+	after, err := c.getCommittedEntry(ctx, repository, commitID, key)
+	if err != nil {
+		return nil, err
+	}
+	if after == nil {
+		return nil, graveler.ErrNotFound
+	}
+
+	provenance := &Provenance{Entry: after}
+	_, err = c.walkPathVersions(ctx, repository, commitID, "", key, after, func(diff *PathVersionDiff) bool {
+		if provenance.Commit == nil {
+			provenance.Commit = diff.Commit
+		}
+		if diff.Before != nil {
+			provenance.PriorVersions++
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return provenance, nil
+}
+
+// LastModifiedCommit returns the commit that last changed the committed version of path in reference. History is
+// walked following first parents and stops at the first commit where the physical address of path differs from its
+// parent, for ProvenanceMaxCommits commits at most. It returns ErrNotFound if path does not exist in reference or
+// the commit was not reached.
+func (c *Catalog) LastModifiedCommit(ctx context.Context, repositoryID, reference, path string) (*CommitLog, error) {
+	if err := validator.Validate([]validator.ValidateArg{
+		{Name: "repository", Value: repositoryID, Fn: graveler.ValidateRepositoryID},
+		{Name: "ref", Value: graveler.Ref(reference), Fn: graveler.ValidateRef},
+		{Name: "path", Value: Path(path), Fn: ValidatePath},
+	}); err != nil {
+		return nil, err
+	}
+	repository, err := c.getRepository(ctx, repositoryID)
+	if err != nil {
+		return nil, err
+	}
+	commitID, err := c.dereferenceCommitID(ctx, repository, graveler.Ref(reference))
+	if err != nil {
+		return nil, err
+	}
+	key := graveler.Key(path)
+	after, err := c.getCommittedEntry(ctx, repository, commitID, key)
+	if err != nil {
+		return nil, err
+	}
+	if after == nil {
+		return nil, graveler.ErrNotFound
+	}
+
+	var lastModified *CommitLog
+	_, err = c.walkPathVersions(ctx, repository, commitID, "", key, after, func(diff *PathVersionDiff) bool {
+		lastModified = diff.Commit
+		return false
+	})
+	if err != nil {
+		return nil, err
+	}
+	if lastModified == nil {
+		return nil, graveler.ErrNotFound
+	}
+	return lastModified, nil
+}
+
+// walkEnd is the reason a history walk ended
+type walkEnd int
+
+const (
+	// walkEndHistory - the first commit of the history was walked
+	walkEndHistory walkEnd = iota
+	// walkEndUntil - the commit to walk until was reached
+	walkEndUntil
+	// walkEndStopped - the callback stopped the walk
+	walkEndStopped
+	// walkEndLimit - ProvenanceMaxCommits commits were walked
+	walkEndLimit
+)
+
+// walkPathVersions walks the history of commitID following first parents, for ProvenanceMaxCommits commits at most,
+// and calls fn, most recent first, for each commit that changed the physical address of key. after is the committed
+// entry of key in commitID. The walk ends before until, if set, or when fn returns false.
+func (c *Catalog) walkPathVersions(ctx context.Context, repository *graveler.RepositoryRecord, commitID, until graveler.CommitID, key graveler.Key, after *DBEntry, fn func(diff *PathVersionDiff) bool) (walkEnd, error) {
+	it, err := c.Store.Log(ctx, repository, commitID, true, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer it.Close()
+
+	for i := 0; i < ProvenanceMaxCommits; i++ {
+		if !it.Next() {
+			if err := it.Err(); err != nil {
+				return 0, err
+			}
+			return walkEndHistory, nil
+		}
+		commit := it.Value()
+		if until != "" && commit.CommitID == until {
+			return walkEndUntil, nil
+		}
+		var before *DBEntry
+		if len(commit.Parents) > 0 {
+			before, err = c.getCommittedEntry(ctx, repository, commit.Parents[0], key)
+			if err != nil {
+				return 0, err
+			}
+		}
+		if physicalAddressChanged(before, after) {
+			if !fn(&PathVersionDiff{Commit: CommitRecordToLog(commit), Before: before, After: after}) {
+				return walkEndStopped, nil
+			}
+		}
+		after = before
+	}
+	return walkEndLimit, nil
+}
+
+// ListObjectsWithCommit lists the committed entries of reference under prefix, each with the commit that last
+// modified it. Rather than walking history per entry, history is walked once for the whole page: each first-parent
+// commit is diffed against its parent over the key range of the page only, and pending entries changed by it are
+// assigned to it. The cost is one ranged diff per commit walked, until every entry in the page is resolved or
+// ListWithCommitMaxCommits commits were walked, in which case the remaining entries are returned with a nil Commit.
+// Uncommitted changes on a branch are not listed.
+func (c *Catalog) ListObjectsWithCommit(ctx context.Context, repositoryID, reference, prefix string, limit int, after string) ([]*EntryWithCommit, bool, error) {
+	if limit < 0 || limit > ListEntriesLimitMax {
+		limit = ListEntriesLimitMax
+	}
+	prefixPath := Path(prefix)
+	afterPath := Path(after)
+	if err := validator.Validate([]validator.ValidateArg{
+		{Name: "repository", Value: repositoryID, Fn: graveler.ValidateRepositoryID},
+		{Name: "ref", Value: graveler.Ref(reference), Fn: graveler.ValidateRef},
+		{Name: "prefix", Value: prefixPath, Fn: ValidatePathOptional},
+		{Name: "after", Value: afterPath, Fn: ValidatePathOptional},
+	}); err != nil {
+		return nil, false, err
+	}
+	repository, err := c.getRepository(ctx, repositoryID)
+	if err != nil {
+		return nil, false, err
+	}
+	commitID, err := c.dereferenceCommitID(ctx, repository, graveler.Ref(reference))
+	if err != nil {
+		return nil, false, err
+	}
+
+	// list the page from the commit, ignoring any staged changes
+	iter, err := c.Store.List(ctx, repository, graveler.Ref(commitID), limit+1)
+	if err != nil {
+		return nil, false, err
+	}
+	it := NewEntryListingIterator(NewValueToEntryIterator(iter), prefixPath, "")
+	defer it.Close()
+	if afterPath != "" {
+		it.SeekGE(afterPath)
+	}
+	var entries []*EntryWithCommit
+	for len(entries) < limit+1 && it.Next() {
+		v := it.Value()
+		if v.Path == afterPath {
+			continue
+		}
+		entries = append(entries, &EntryWithCommit{DBEntry: newCatalogEntryFromEntry(false, v.Path.String(), v.Entry)})
+	}
+	if err := it.Err(); err != nil {
+		return nil, false, err
+	}
+	hasMore := false
+	if len(entries) > limit {
+		hasMore = true
+		entries = entries[:limit]
+	}
+	if len(entries) == 0 {
+		return entries, hasMore, nil
+	}
+
+	pending := make(map[string]*EntryWithCommit, len(entries))
+	for _, entry := range entries {
+		pending[entry.Path] = entry
+	}
+	firstKey := graveler.Key(entries[0].Path)
+	lastKey := graveler.Key(entries[len(entries)-1].Path)
+
+	commits, err := c.Store.Log(ctx, repository, commitID, true, nil)
+	if err != nil {
+		return nil, false, err
+	}
+	defer commits.Close()
+	for i := 0; i < ListWithCommitMaxCommits && len(pending) > 0 && commits.Next(); i++ {
+		commit := commits.Value()
+		commitLog := CommitRecordToLog(commit)
+		if len(commit.Parents) == 0 {
+			// everything still pending was introduced by the root commit
+			for path, entry := range pending {
+				entry.Commit = commitLog
+				delete(pending, path)
+			}
+			break
+		}
+		if err := c.resolveChangedEntries(ctx, repository, commit.Parents[0], commit.CommitID, firstKey, lastKey, pending, commitLog); err != nil {
+			return nil, false, err
+		}
+	}
+	if err := commits.Err(); err != nil {
+		return nil, false, err
+	}
+	return entries, hasMore, nil
+}
+
+// resolveChangedEntries assigns commitLog to the pending entries changed between parentID and commitID, looking
+// only at keys between firstKey and lastKey, and removes them from pending.
+func (c *Catalog) resolveChangedEntries(ctx context.Context, repository *graveler.RepositoryRecord, parentID, commitID graveler.CommitID, firstKey, lastKey graveler.Key, pending map[string]*EntryWithCommit, commitLog *CommitLog) error {
+	diffs, err := c.Store.Diff(ctx, repository, graveler.Ref(parentID), graveler.Ref(commitID))
+	if err != nil {
+		return err
+	}
+	defer diffs.Close()
+	diffs.SeekGE(firstKey)
+	for len(pending) > 0 && diffs.Next() {
+		key := diffs.Value().Key
+		if bytes.Compare(key, lastKey) > 0 {
+			break
+		}
+		if entry, ok := pending[key.String()]; ok {
+			entry.Commit = commitLog
+			delete(pending, key.String())
+		}
+	}
+	return diffs.Err()
+}
+
+// getCommittedEntry returns the entry of key in commitID, or nil if the key is not found
+func (c *Catalog) getCommittedEntry(ctx context.Context, repository *graveler.RepositoryRecord, commitID graveler.CommitID, key graveler.Key) (*DBEntry, error) {
+	val, err := c.Store.GetByCommitID(ctx, repository, commitID, key)
+	if errors.Is(err, graveler.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	ent, err := ValueToEntry(val)
+	if err != nil {
+		return nil, err
+	}
+	entry := newCatalogEntryFromEntry(false, key.String(), ent)
+	return &entry, nil
+}
+
+func physicalAddressChanged(before, after *DBEntry) bool {
+	if before == nil || after == nil {
+		return before != after
+	}
+	return before.PhysicalAddress != after.PhysicalAddress
+}
+
+func (c *Catalog) listCommitsWithPaths(ctx context.Context, repository *graveler.RepositoryRecord, it graveler.CommitIterator, params LogParams) ([]*CommitLog, bool, error) {
+	// verify we are not listing commits without any paths
+	if len(params.PathList) == 0 {
+		return nil, false, fmt.Errorf("%w: list commits without paths", graveler.ErrInvalid)
+	}
+
+	// commit/key to value cache - helps when fetching the same commit/key while processing parent commits
+	const commitLogCacheSize = 1024 * 5
+	commitCache, err := lru.New(commitLogCacheSize)
+	if err != nil {
+		return nil, false, err
+	}
+
+	const numReadResults = 3
+	done := atomic.NewBool(false)
+
+	// Shared workPool for the workers. 2 designated to create the work and receive the result
+	paths := params.PathList
 
 	// iterate over commits log and push work into the work channel
 	outCh := make(chan *commitLogJob, numReadResults)
@@ -1400,8 +3077,8 @@ func (c *Catalog) listCommitsWithPaths(ctx context.Context, repository *graveler
 			}
 
 			commitRecord := it.Value()
-			// skip merge commits
-			if len(commitRecord.Parents) != NumberOfParentsOfNonMergeCommit {
+			// skip merge commits, unless asked for merge commits only - these are checked against their first parent
+			if len(commitRecord.Parents) != NumberOfParentsOfNonMergeCommit && !params.MergesOnly {
 				continue
 			}
 
@@ -1486,7 +3163,6 @@ func listCommitsWithoutPaths(it graveler.CommitIterator, params LogParams) ([]*C
 	var commits []*CommitLog
 	for it.Next() {
 		val := it.Value()
-
 		commits = append(commits, CommitRecordToLog(val))
 		if foundAllCommits(params, commits) {
 			// All results returned until the last commit found
@@ -1502,16 +3178,56 @@ func listCommitsWithoutPaths(it graveler.CommitIterator, params LogParams) ([]*C
 	return logCommitsResult(commits, params)
 }
 
-func foundAllCommits(params LogParams, commits []*CommitLog) bool {
-	return (params.Limit && len(commits) >= params.Amount) ||
-		len(commits) >= params.Amount+1 || (len(commits) > 0 && commits[len(commits)-1].Reference == params.StopAt)
+// commitFilterIterator iterates the commits of a log that match the commit filters of params. The log ends at the
+// params StopAt commit even if it does not match, and fails with ErrHistoryTooLong once more than LogFilterMaxCommits
+// commits were scanned.
+type commitFilterIterator struct {
+	graveler.CommitIterator
+	params  LogParams
+	scanned int
+	done    bool
+	err     error
 }
 
-func CommitRecordToLog(val *graveler.CommitRecord) *CommitLog {
-	if val == nil {
-		return nil
+func (it *commitFilterIterator) Next() bool {
+	if it.done || it.err != nil {
+		return false
 	}
-	commit := &CommitLog{
+	for it.CommitIterator.Next() {
+		it.scanned++
+		if it.scanned > LogFilterMaxCommits {
+			it.err = fmt.Errorf("filter commits: %w", ErrHistoryTooLong)
+			return false
+		}
+		val := it.CommitIterator.Value()
+		if it.params.filterCommit(val) {
+			return true
+		}
+		if val.CommitID.String() == it.params.StopAt {
+			it.done = true
+			return false
+		}
+	}
+	return false
+}
+
+func (it *commitFilterIterator) Err() error {
+	if it.err != nil {
+		return it.err
+	}
+	return it.CommitIterator.Err()
+}
+
+func foundAllCommits(params LogParams, commits []*CommitLog) bool {
+	return (params.Limit && len(commits) >= params.Amount) ||
+		len(commits) >= params.Amount+1 || (len(commits) > 0 && commits[len(commits)-1].Reference == params.StopAt)
+}
+
+func CommitRecordToLog(val *graveler.CommitRecord) *CommitLog {
+	if val == nil {
+		return nil
+	}
+	commit := &CommitLog{
 		Reference:    val.CommitID.String(),
 		Committer:    val.Committer,
 		Message:      val.Message,
@@ -1568,9 +3284,35 @@ func (h *commitLogJobHeap) Pop() interface{} {
 	return x
 }
 
+// addressHeap heap of addresses. The maximum element in the tree is the root, at index 0.
+type addressHeap []string
+
+//goland:noinspection GoMixedReceiverTypes
+func (h addressHeap) Len() int { return len(h) }
+
+//goland:noinspection GoMixedReceiverTypes
+func (h addressHeap) Less(i, j int) bool { return h[i] > h[j] }
+
+//goland:noinspection GoMixedReceiverTypes
+func (h addressHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+//goland:noinspection GoMixedReceiverTypes
+func (h *addressHeap) Push(x interface{}) {
+	*h = append(*h, x.(string))
+}
+
+//goland:noinspection GoMixedReceiverTypes
+func (h *addressHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[0 : n-1]
+	return x
+}
+
 // checkPathListInCommit checks whether the given commit contains changes to a list of paths.
-// it searches the path in the diff between the commit, and it's parent, but do so only to commits
-// that have single parent (not merge commits)
+// it searches the path in the diff between the commit, and it's first parent, the log calls it for merge
+// commits only when listing merge commits
 func (c *Catalog) checkPathListInCommit(ctx context.Context, repository *graveler.RepositoryRecord, commit *graveler.CommitRecord, pathList []PathRecord, commitCache *lru.Cache) (bool, error) {
 	left := commit.Parents[0]
 	right := commit.CommitID
@@ -1690,10 +3432,146 @@ func (c *Catalog) Revert(ctx context.Context, repositoryID string, branch string
 	if err != nil {
 		return err
 	}
+	if err := c.checkMaintenance(repository); err != nil {
+		return err
+	}
 	_, err = c.Store.Revert(ctx, repository, branchID, reference, parentNumber, commitParams, params.CommitOverrides, opts...)
 	return err
 }
 
+// ExportCommitPatch writes the changes of the commit at reference, compared to its first parent, to w as a patch: one
+// JSON encoded PatchRecord per line, in path order. Entry addresses are written as full addresses, so the patch can
+// be applied with ApplyCommitPatch on a branch of any repository that can access the same storage. The objects are
+// not copied: they must not be garbage collected from this repository while the patch is in use.
+func (c *Catalog) ExportCommitPatch(ctx context.Context, repositoryID, reference string, w io.Writer) error {
+	if err := validator.Validate([]validator.ValidateArg{
+		{Name: "repository", Value: repositoryID, Fn: graveler.ValidateRepositoryID},
+		{Name: "ref", Value: graveler.Ref(reference), Fn: graveler.ValidateRef},
+	}); err != nil {
+		return err
+	}
+	repository, err := c.getRepository(ctx, repositoryID)
+	if err != nil {
+		return err
+	}
+	commitID, err := c.dereferenceCommitID(ctx, repository, graveler.Ref(reference))
+	if err != nil {
+		return err
+	}
+	commit, err := c.Store.GetCommit(ctx, repository, commitID)
+	if err != nil {
+		return err
+	}
+	if len(commit.Parents) == 0 {
+		return fmt.Errorf("commit %s has no parent: %w", commitID, graveler.ErrInvalidValue)
+	}
+	it, err := c.Store.Diff(ctx, repository, graveler.Ref(commit.Parents[0]), graveler.Ref(commitID))
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+
+	enc := json.NewEncoder(w)
+	for it.Next() {
+		d := it.Value()
+		record := PatchRecord{Path: d.Key.String()}
+		switch d.Type {
+		case graveler.DiffTypeAdded:
+			record.Type = PatchTypeAdded
+		case graveler.DiffTypeChanged:
+			record.Type = PatchTypeChanged
+		case graveler.DiffTypeRemoved:
+			record.Type = PatchTypeRemoved
+		default:
+			return fmt.Errorf("%s: %d: %w", record.Path, d.Type, ErrUnknownDiffType)
+		}
+		if d.Value != nil {
+			ent, err := ValueToEntry(d.Value)
+			if err != nil {
+				return err
+			}
+			qk, err := c.BlockAdapter.ResolveNamespace(repository.StorageNamespace.String(), ent.Address, addressTypeToCatalog(ent.AddressType).ToIdentifierType())
+			if err != nil {
+				return fmt.Errorf("resolve address of %s: %w", record.Path, err)
+			}
+			entry := newCatalogEntryFromEntry(false, record.Path, ent)
+			entry.PhysicalAddress = qk.Format()
+			entry.AddressType = AddressTypeFull
+			record.Entry = &entry
+		}
+		if err := enc.Encode(record); err != nil {
+			return err
+		}
+	}
+	return it.Err()
+}
+
+// ApplyCommitPatch stages the changes of a patch written by ExportCommitPatch on branch. The whole patch is read and
+// validated before anything is staged. Changes are staged one by one and not committed.
+func (c *Catalog) ApplyCommitPatch(ctx context.Context, repositoryID, branch string, r io.Reader, opts ...graveler.SetOptionsFunc) error {
+	branchID := graveler.BranchID(branch)
+	if err := validator.Validate([]validator.ValidateArg{
+		{Name: "repository", Value: repositoryID, Fn: graveler.ValidateRepositoryID},
+		{Name: "branch", Value: branchID, Fn: graveler.ValidateBranchID},
+	}); err != nil {
+		return err
+	}
+
+	var records []PatchRecord
+	dec := json.NewDecoder(r)
+	for {
+		var record PatchRecord
+		err := dec.Decode(&record)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("read patch: %w", err)
+		}
+		if err := ValidatePath(Path(record.Path)); err != nil {
+			return fmt.Errorf("patch record %d: %w", len(records), err)
+		}
+		switch record.Type {
+		case PatchTypeAdded, PatchTypeChanged:
+			if record.Entry == nil {
+				return fmt.Errorf("patch record %d: %s missing entry: %w", len(records), record.Path, graveler.ErrInvalidValue)
+			}
+		case PatchTypeRemoved:
+		default:
+			return fmt.Errorf("patch record %d: %s: %w", len(records), record.Type, ErrUnknownDiffType)
+		}
+		records = append(records, record)
+	}
+
+	repository, err := c.getRepository(ctx, repositoryID)
+	if err != nil {
+		return err
+	}
+	if err := c.checkMaintenance(repository); err != nil {
+		return err
+	}
+	for _, record := range records {
+		key := graveler.Key(record.Path)
+		if record.Type == PatchTypeRemoved {
+			err = c.Store.Delete(ctx, repository, branchID, key, opts...)
+			if errors.Is(err, graveler.ErrNotFound) {
+				continue // already removed on branch
+			}
+		} else {
+			var value *graveler.Value
+			value, err = EntryToValue(newEntryFromCatalogEntry(*record.Entry))
+			if err != nil {
+				return err
+			}
+			err = c.Store.Set(ctx, repository, branchID, key, *value, opts...)
+		}
+		if err != nil {
+			return fmt.Errorf("apply %s: %w", record.Path, err)
+		}
+	}
+	return nil
+}
+
 func (c *Catalog) CherryPick(ctx context.Context, repositoryID string, branch string, params CherryPickParams, opts ...graveler.SetOptionsFunc) (*CommitLog, error) {
 	branchID := graveler.BranchID(branch)
 	reference := graveler.Ref(params.Reference)
@@ -1711,6 +3589,9 @@ func (c *Catalog) CherryPick(ctx context.Context, repositoryID string, branch st
 	if err != nil {
 		return nil, err
 	}
+	if err := c.checkMaintenance(repository); err != nil {
+		return nil, err
+	}
 
 	commitID, err := c.Store.CherryPick(ctx, repository, branchID, reference, parentNumber, params.Committer, params.CommitOverrides, opts...)
 	if err != nil {
@@ -1763,22 +3644,41 @@ func (c *Catalog) Diff(ctx context.Context, repositoryID string, leftReference s
 	return listDiffHelper(it, params.Prefix, params.Delimiter, params.Limit, params.After)
 }
 
-func (c *Catalog) Compare(ctx context.Context, repositoryID, leftReference string, rightReference string, params DiffParams) (Differences, bool, error) {
-	left := graveler.Ref(leftReference)
-	right := graveler.Ref(rightReference)
+// DiffCommit returns the changes a commit made relative to one of its parents: a two-way diff from the parent to
+// the commit. parentNumber is 1-based, as in Revert; 0 selects the first parent. A commit without parents has
+// nothing to diff against and returns ErrParentOutOfRange.
+func (c *Catalog) DiffCommit(ctx context.Context, repositoryID string, commitRef string, parentNumber int, params DiffParams) (Differences, bool, error) {
+	ref := graveler.Ref(commitRef)
 	if err := validator.Validate([]validator.ValidateArg{
-		{Name: "repositoryName", Value: repositoryID, Fn: graveler.ValidateRepositoryID},
-		{Name: "left", Value: left, Fn: graveler.ValidateRef},
-		{Name: "right", Value: right, Fn: graveler.ValidateRef},
+		{Name: "repository", Value: repositoryID, Fn: graveler.ValidateRepositoryID},
+		{Name: "commit", Value: ref, Fn: graveler.ValidateRef},
 	}); err != nil {
 		return nil, false, err
 	}
+	if parentNumber < 0 {
+		return nil, false, fmt.Errorf("parent %d: %w", parentNumber, graveler.ErrInvalidValue)
+	}
 	repository, err := c.getRepository(ctx, repositoryID)
 	if err != nil {
 		return nil, false, err
 	}
+	commitID, err := c.dereferenceCommitID(ctx, repository, ref)
+	if err != nil {
+		return nil, false, err
+	}
+	commit, err := c.Store.GetCommit(ctx, repository, commitID)
+	if err != nil {
+		return nil, false, err
+	}
+	if parentNumber == 0 {
+		parentNumber = 1
+	}
+	if parentNumber > len(commit.Parents) {
+		return nil, false, fmt.Errorf("parent %d: %w", parentNumber, graveler.ErrParentOutOfRange)
+	}
+	parentID := commit.Parents[parentNumber-1]
 
-	iter, err := c.Store.Compare(ctx, repository, left, right)
+	iter, err := c.Store.Diff(ctx, repository, graveler.Ref(parentID), graveler.Ref(commitID))
 	if err != nil {
 		return nil, false, err
 	}
@@ -1787,104 +3687,640 @@ func (c *Catalog) Compare(ctx context.Context, repositoryID, leftReference strin
 	return listDiffHelper(it, params.Prefix, params.Delimiter, params.Limit, params.After)
 }
 
-func (c *Catalog) DiffUncommitted(ctx context.Context, repositoryID, branch, prefix, delimiter string, limit int, after string) (Differences, bool, error) {
-	branchID := graveler.BranchID(branch)
+// DiffSummary counts the differences between leftReference and rightReference and sums the sizes of the entries
+// involved, without returning the differences themselves. Only the size field of each entry is decoded. A changed
+// path adds its right size to BytesAdded and its left size, read from the commit leftReference resolves to, to
+// BytesRemoved.
+func (c *Catalog) DiffSummary(ctx context.Context, repositoryID, leftReference, rightReference string) (*DiffStats, error) {
+	left := graveler.Ref(leftReference)
+	right := graveler.Ref(rightReference)
 	if err := validator.Validate([]validator.ValidateArg{
 		{Name: "repository", Value: repositoryID, Fn: graveler.ValidateRepositoryID},
-		{Name: "branch", Value: branchID, Fn: graveler.ValidateBranchID},
+		{Name: "left", Value: left, Fn: graveler.ValidateRef},
+		{Name: "right", Value: right, Fn: graveler.ValidateRef},
 	}); err != nil {
-		return nil, false, err
+		return nil, err
 	}
 	repository, err := c.getRepository(ctx, repositoryID)
 	if err != nil {
-		return nil, false, err
+		return nil, err
 	}
 
-	iter, err := c.Store.DiffUncommitted(ctx, repository, branchID)
+	// the diff compares the committed state of left, so changed entries are read from the same left commit
+	leftRef, err := c.Store.Dereference(ctx, repository, left)
 	if err != nil {
-		return nil, false, err
+		return nil, err
+	}
+	leftCommitID := leftRef.CommitID
+
+	it, err := c.Store.Diff(ctx, repository, graveler.Ref(leftCommitID), right)
+	if err != nil {
+		return nil, err
 	}
-	it := NewEntryDiffIterator(iter)
 	defer it.Close()
-	return listDiffHelper(it, prefix, delimiter, limit, after)
+
+	stats := &DiffStats{}
+	for it.Next() {
+		d := it.Value()
+		// the diff value is the right value, except for a removed path where it is the left value
+		var size int64
+		if d.Value != nil {
+			size, err = ValueToEntrySize(d.Value)
+			if err != nil {
+				return nil, err
+			}
+		}
+		switch d.Type {
+		case graveler.DiffTypeAdded:
+			stats.Added++
+			stats.BytesAdded += size
+		case graveler.DiffTypeRemoved:
+			stats.Removed++
+			stats.BytesRemoved += size
+		case graveler.DiffTypeChanged:
+			stats.Changed++
+			stats.BytesAdded += size
+			leftValue, err := c.Store.GetByCommitID(ctx, repository, leftCommitID, d.Key)
+			if err != nil {
+				return nil, fmt.Errorf("get %s from left: %w", d.Key, err)
+			}
+			leftSize, err := ValueToEntrySize(leftValue)
+			if err != nil {
+				return nil, err
+			}
+			stats.BytesRemoved += leftSize
+		}
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	return stats, nil
 }
 
-// GetStartPos returns a key that SeekGE will transform to a place start iterating on all elements in
-//
-//	the keys that start with 'prefix' after 'after' and taking 'delimiter' into account
-func GetStartPos(prefix, after, delimiter string) string {
-	if after == "" {
-		// whether we have a delimiter or not, if after is not set, start at prefix
-		return prefix
+// GetRepositoryStats returns the number of branches of the repository and, as of the default branch commit, the number
+// of commits reachable from it and the number and total size of the objects it holds. Uncommitted objects, and commits
+// and objects only found on other branches, are not counted. Stats are computed by walking the default branch history
+// and reading all the entries of its commit, so the call takes time proportional to the number of commits and objects.
+func (c *Catalog) GetRepositoryStats(ctx context.Context, repositoryID string) (*RepositoryStats, error) {
+	if err := validator.Validate([]validator.ValidateArg{
+		{Name: "repository", Value: repositoryID, Fn: graveler.ValidateRepositoryID},
+	}); err != nil {
+		return nil, err
 	}
-	if after < prefix {
-		// if after is before prefix, no point in starting there, start with prefix instead
-		return prefix
+	repository, err := c.getRepository(ctx, repositoryID)
+	if err != nil {
+		return nil, err
 	}
-	if delimiter == "" {
-		// no delimiter, continue from after
-		return after
+
+	stats := &RepositoryStats{}
+	branches, err := c.Store.ListBranches(ctx, repository)
+	if err != nil {
+		return nil, err
 	}
-	// there is a delimiter and after is not empty, start at the next common prefix after "after"
-	return string(graveler.UpperBoundForPrefix([]byte(after)))
+	defer branches.Close()
+	for branches.Next() {
+		stats.Branches++
+	}
+	if err := branches.Err(); err != nil {
+		return nil, err
+	}
+
+	defaultBranch, err := c.Store.GetBranch(ctx, repository, repository.DefaultBranchID)
+	if err != nil {
+		return nil, fmt.Errorf("default branch: %w", err)
+	}
+	stats.CommitID = defaultBranch.CommitID.String()
+	if stats.CommitID == "" {
+		// bare repository, nothing committed yet
+		return stats, nil
+	}
+	commits, err := c.Store.Log(ctx, repository, defaultBranch.CommitID, false, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer commits.Close()
+	for commits.Next() {
+		stats.Commits++
+	}
+	if err := commits.Err(); err != nil {
+		return nil, err
+	}
+
+	it, err := c.Store.List(ctx, repository, defaultBranch.CommitID.Ref(), ListEntriesLimitMax)
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+	for it.Next() {
+		size, err := ValueToEntrySize(it.Value().Value)
+		if err != nil {
+			return nil, err
+		}
+		stats.Objects++
+		stats.Bytes += size
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	return stats, nil
 }
 
-const commonPrefixSplitParts = 2
+// EstimateDiffSize returns an approximate number of changed entries between the commits of leftReference and
+// rightReference. Only range metadata is read, so the result is an estimate and not an exact diff size.
+// Uncommitted changes are not included.
+func (c *Catalog) EstimateDiffSize(ctx context.Context, repositoryID, leftReference, rightReference string) (int, error) {
+	left := graveler.Ref(leftReference)
+	right := graveler.Ref(rightReference)
+	if err := validator.Validate([]validator.ValidateArg{
+		{Name: "repository", Value: repositoryID, Fn: graveler.ValidateRepositoryID},
+		{Name: "left", Value: left, Fn: graveler.ValidateRef},
+		{Name: "right", Value: right, Fn: graveler.ValidateRef},
+	}); err != nil {
+		return 0, err
+	}
+	repository, err := c.getRepository(ctx, repositoryID)
+	if err != nil {
+		return 0, err
+	}
+	return c.Store.EstimateDiffSize(ctx, repository, left, right)
+}
 
-func listDiffHelper(it EntryDiffIterator, prefix, delimiter string, limit int, after string) (Differences, bool, error) {
+// ListDeletedPaths returns the paths found in fromReference and missing in toReference, sorted and paginated by
+// limit and after. It is the removals-only part of Diff.
+func (c *Catalog) ListDeletedPaths(ctx context.Context, repositoryID, fromReference, toReference string, limit int, after string) ([]string, bool, error) {
 	if limit < 0 || limit > DiffLimitMax {
 		limit = DiffLimitMax
 	}
-	seekStart := GetStartPos(prefix, after, delimiter)
-	it.SeekGE(Path(seekStart))
+	left := graveler.Ref(fromReference)
+	right := graveler.Ref(toReference)
+	if err := validator.Validate([]validator.ValidateArg{
+		{Name: "repository", Value: repositoryID, Fn: graveler.ValidateRepositoryID},
+		{Name: "from", Value: left, Fn: graveler.ValidateRef},
+		{Name: "to", Value: right, Fn: graveler.ValidateRef},
+	}); err != nil {
+		return nil, false, err
+	}
+	repository, err := c.getRepository(ctx, repositoryID)
+	if err != nil {
+		return nil, false, err
+	}
+	it, err := c.Store.Diff(ctx, repository, left, right)
+	if err != nil {
+		return nil, false, err
+	}
+	defer it.Close()
+
+	it.SeekGE(graveler.Key(after))
+	paths := make([]string, 0)
+	for it.Next() {
+		d := it.Value()
+		if d.Type != graveler.DiffTypeRemoved {
+			continue
+		}
+		path := d.Key.String()
+		if path == after {
+			continue // emulate SeekGT using SeekGE
+		}
+		paths = append(paths, path)
+		if len(paths) > limit {
+			break
+		}
+	}
+	if err := it.Err(); err != nil {
+		return nil, false, err
+	}
+	hasMore := false
+	if len(paths) > limit {
+		hasMore = true
+		paths = paths[:limit]
+	}
+	return paths, hasMore, nil
+}
+
+func (c *Catalog) Compare(ctx context.Context, repositoryID, leftReference string, rightReference string, params DiffParams) (Differences, bool, error) {
+	left := graveler.Ref(leftReference)
+	right := graveler.Ref(rightReference)
+	if err := validator.Validate([]validator.ValidateArg{
+		{Name: "repositoryName", Value: repositoryID, Fn: graveler.ValidateRepositoryID},
+		{Name: "left", Value: left, Fn: graveler.ValidateRef},
+		{Name: "right", Value: right, Fn: graveler.ValidateRef},
+	}); err != nil {
+		return nil, false, err
+	}
+	repository, err := c.getRepository(ctx, repositoryID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	iter, err := c.Store.Compare(ctx, repository, left, right)
+	if err != nil {
+		return nil, false, err
+	}
+	it := NewEntryDiffIterator(iter)
+	defer it.Close()
+	return listDiffHelper(it, params.Prefix, params.Delimiter, params.Limit, params.After)
+}
+
+// CompareWithBase returns the changes made on rightReference since baseReference, compared with leftReference.
+// It is Compare with a merge base chosen by the caller, for example to preview a rebase onto baseReference.
+func (c *Catalog) CompareWithBase(ctx context.Context, repositoryID, leftReference, rightReference, baseReference string, params DiffParams) (Differences, bool, error) {
+	left := graveler.Ref(leftReference)
+	right := graveler.Ref(rightReference)
+	base := graveler.Ref(baseReference)
+	if err := validator.Validate([]validator.ValidateArg{
+		{Name: "repositoryName", Value: repositoryID, Fn: graveler.ValidateRepositoryID},
+		{Name: "left", Value: left, Fn: graveler.ValidateRef},
+		{Name: "right", Value: right, Fn: graveler.ValidateRef},
+		{Name: "base", Value: base, Fn: graveler.ValidateRef},
+	}); err != nil {
+		return nil, false, err
+	}
+	repository, err := c.getRepository(ctx, repositoryID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	iter, err := c.Store.CompareWithBase(ctx, repository, left, right, base)
+	if err != nil {
+		return nil, false, err
+	}
+	it := NewEntryDiffIterator(iter)
+	defer it.Close()
+	return listDiffHelper(it, params.Prefix, params.Delimiter, params.Limit, params.After)
+}
+
+func (c *Catalog) DiffUncommitted(ctx context.Context, repositoryID, branch, prefix, delimiter string, limit int, after string) (Differences, bool, error) {
+	branchID := graveler.BranchID(branch)
+	if err := validator.Validate([]validator.ValidateArg{
+		{Name: "repository", Value: repositoryID, Fn: graveler.ValidateRepositoryID},
+		{Name: "branch", Value: branchID, Fn: graveler.ValidateBranchID},
+	}); err != nil {
+		return nil, false, err
+	}
+	repository, err := c.getRepository(ctx, repositoryID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	iter, err := c.Store.DiffUncommitted(ctx, repository, branchID)
+	if err != nil {
+		return nil, false, err
+	}
+	it := NewEntryDiffIterator(iter)
+	defer it.Close()
+	return listDiffHelper(it, prefix, delimiter, limit, after)
+}
+
+// DiffUncommittedDetailed lists the uncommitted changes of branch, each with both the committed and the staged entry
+// of the path, to compare old and new values. Results are ordered by path and paginated like DiffUncommitted.
+func (c *Catalog) DiffUncommittedDetailed(ctx context.Context, repositoryID, branch string, limit int, after string) ([]*UncommittedChange, bool, error) {
+	if limit < 0 || limit > DiffLimitMax {
+		limit = DiffLimitMax
+	}
+	branchID := graveler.BranchID(branch)
+	if err := validator.Validate([]validator.ValidateArg{
+		{Name: "repository", Value: repositoryID, Fn: graveler.ValidateRepositoryID},
+		{Name: "branch", Value: branchID, Fn: graveler.ValidateBranchID},
+		{Name: "after", Value: Path(after), Fn: ValidatePathOptional},
+	}); err != nil {
+		return nil, false, err
+	}
+	repository, err := c.getRepository(ctx, repositoryID)
+	if err != nil {
+		return nil, false, err
+	}
+	b, err := c.Store.GetBranch(ctx, repository, branchID)
+	if err != nil {
+		return nil, false, err
+	}
+	it, err := c.Store.DiffUncommitted(ctx, repository, branchID)
+	if err != nil {
+		return nil, false, err
+	}
+	defer it.Close()
+	it.SeekGE(graveler.Key(after))
+
+	changes := make([]*UncommittedChange, 0)
+	for it.Next() {
+		v := it.Value()
+		path := v.Key.String()
+		if path == after {
+			continue // emulate SeekGT using SeekGE
+		}
+		if len(changes) >= limit {
+			return changes, true, nil
+		}
+		typ, err := catalogDiffType(v.Type)
+		if err != nil {
+			return nil, false, err
+		}
+		change := &UncommittedChange{Path: path, Type: typ}
+		if v.Type != graveler.DiffTypeAdded {
+			change.Committed, err = c.getCommittedEntry(ctx, repository, b.CommitID, v.Key)
+			if err != nil {
+				return nil, false, err
+			}
+		}
+		if v.Value != nil {
+			ent, err := ValueToEntry(v.Value)
+			if err != nil {
+				return nil, false, err
+			}
+			staged := newCatalogEntryFromEntry(false, path, ent)
+			change.Staged = &staged
+		}
+		changes = append(changes, change)
+	}
+	if err := it.Err(); err != nil {
+		return nil, false, err
+	}
+	return changes, false, nil
+}
+
+// CountUncommittedChanges returns the number of uncommitted changes of each branch in the repository that has any.
+// Changes are counted by listing the uncommitted diff of every branch.
+func (c *Catalog) CountUncommittedChanges(ctx context.Context, repositoryID string) (map[string]int, error) {
+	if err := validator.Validate([]validator.ValidateArg{
+		{Name: "repository", Value: repositoryID, Fn: graveler.ValidateRepositoryID},
+	}); err != nil {
+		return nil, err
+	}
+	repository, err := c.getRepository(ctx, repositoryID)
+	if err != nil {
+		return nil, err
+	}
+	branchIt, err := c.Store.ListBranches(ctx, repository)
+	if err != nil {
+		return nil, err
+	}
+	defer branchIt.Close()
+
+	counts := make(map[string]int)
+	for branchIt.Next() {
+		branchID := branchIt.Value().BranchID
+		count, err := c.countUncommitted(ctx, repository, branchID)
+		if err != nil {
+			return nil, fmt.Errorf("branch %s: %w", branchID, err)
+		}
+		if count > 0 {
+			counts[branchID.String()] = count
+		}
+	}
+	if err := branchIt.Err(); err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+func (c *Catalog) countUncommitted(ctx context.Context, repository *graveler.RepositoryRecord, branchID graveler.BranchID) (int, error) {
+	it, err := c.Store.DiffUncommitted(ctx, repository, branchID)
+	if err != nil {
+		return 0, err
+	}
+	defer it.Close()
+	count := 0
+	for it.Next() {
+		count++
+	}
+	if err := it.Err(); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// HasUncommittedChanges reports whether branch has any uncommitted change. It stops at the first change found, so
+// it is much cheaper than a diff of the uncommitted changes.
+func (c *Catalog) HasUncommittedChanges(ctx context.Context, repositoryID, branch string) (bool, error) {
+	branchID := graveler.BranchID(branch)
+	if err := validator.Validate([]validator.ValidateArg{
+		{Name: "repository", Value: repositoryID, Fn: graveler.ValidateRepositoryID},
+		{Name: "branch", Value: branchID, Fn: graveler.ValidateBranchID},
+	}); err != nil {
+		return false, err
+	}
+	repository, err := c.getRepository(ctx, repositoryID)
+	if err != nil {
+		return false, err
+	}
+	return c.hasUncommitted(ctx, repository, branchID)
+}
+
+func (c *Catalog) hasUncommitted(ctx context.Context, repository *graveler.RepositoryRecord, branchID graveler.BranchID) (bool, error) {
+	it, err := c.Store.DiffUncommitted(ctx, repository, branchID)
+	if err != nil {
+		return false, err
+	}
+	defer it.Close()
+	if it.Next() {
+		return true, nil
+	}
+	return false, it.Err()
+}
+
+// PreviewCommit returns the branch head and the uncommitted changes a commit of branch would capture, without
+// committing. An empty Changes means the commit would be a no-op.
+// The commit ID is not computed: it depends on the creation date and on the metarange, which is only written by the
+// commit itself.
+func (c *Catalog) PreviewCommit(ctx context.Context, repositoryID, branch string) (*CommitPreview, error) {
+	branchID := graveler.BranchID(branch)
+	if err := validator.Validate([]validator.ValidateArg{
+		{Name: "repository", Value: repositoryID, Fn: graveler.ValidateRepositoryID},
+		{Name: "branch", Value: branchID, Fn: graveler.ValidateBranchID},
+	}); err != nil {
+		return nil, err
+	}
+	repository, err := c.getRepository(ctx, repositoryID)
+	if err != nil {
+		return nil, err
+	}
+	b, err := c.Store.GetBranch(ctx, repository, branchID)
+	if err != nil {
+		return nil, err
+	}
+	iter, err := c.Store.DiffUncommitted(ctx, repository, branchID)
+	if err != nil {
+		return nil, err
+	}
+	it := NewEntryDiffIterator(iter)
+	defer it.Close()
+	changes, hasMore, err := listDiffHelper(it, "", "", DiffLimitMax, "")
+	if err != nil {
+		return nil, err
+	}
+	return &CommitPreview{
+		BaseCommitID: b.CommitID.String(),
+		Changes:      changes,
+		HasMore:      hasMore,
+	}, nil
+}
+
+// GetStartPos returns a key that SeekGE will transform to a place start iterating on all elements in
+//
+//	the keys that start with 'prefix' after 'after' and taking 'delimiter' into account
+func GetStartPos(prefix, after, delimiter string) string {
+	if after == "" {
+		// whether we have a delimiter or not, if after is not set, start at prefix
+		return prefix
+	}
+	if after < prefix {
+		// if after is before prefix, no point in starting there, start with prefix instead
+		return prefix
+	}
+	if delimiter == "" {
+		// no delimiter, continue from after
+		return after
+	}
+	// there is a delimiter and after is not empty, start at the next common prefix after "after"
+	return string(graveler.UpperBoundForPrefix([]byte(after)))
+}
+
+const commonPrefixSplitParts = 2
+
+func listDiffHelper(it EntryDiffIterator, prefix, delimiter string, limit int, after string) (Differences, bool, error) {
+	if limit < 0 || limit > DiffLimitMax {
+		limit = DiffLimitMax
+	}
+	seekStart := GetStartPos(prefix, after, delimiter)
+	it.SeekGE(Path(seekStart))
+
+	diffs := make(Differences, 0)
+	for it.Next() {
+		v := it.Value()
+		path := string(v.Path)
+
+		if path == after {
+			continue // emulate SeekGT using SeekGE
+		}
+		if !strings.HasPrefix(path, prefix) {
+			break // we only want things that start with prefix, apparently there are none left
+		}
+
+		if delimiter != "" {
+			// common prefix logic goes here.
+			// for every path, after trimming "prefix", take the string upto-and-including the delimiter
+			// if the received path == the entire remainder, add that object as is
+			// if it's just a part of the name, add it as a "common prefix" entry -
+			//   and skip to next record following all those starting with this prefix
+			pathRelativeToPrefix := strings.TrimPrefix(path, prefix)
+			// we want the common prefix and the remainder
+			parts := strings.SplitN(pathRelativeToPrefix, delimiter, commonPrefixSplitParts)
+			if len(parts) == commonPrefixSplitParts {
+				// a common prefix exists!
+				commonPrefix := prefix + parts[0] + delimiter
+				diffs = append(diffs, Difference{
+					DBEntry: NewDBEntryBuilder().CommonLevel(true).Path(commonPrefix).Build(),
+					// We always return "changed" for common prefixes. Seeing if a common prefix is e.g. deleted is O(N)
+					Type: DifferenceTypePrefixChanged,
+				})
+				if len(diffs) >= limit+1 {
+					break // collected enough results
+				}
+
+				// let's keep collecting records. We want the next record that doesn't
+				//   start with this common prefix
+				it.SeekGE(Path(graveler.UpperBoundForPrefix([]byte(commonPrefix))))
+				continue
+			}
+		}
+
+		// got a regular entry
+		diff, err := newDifferenceFromEntryDiff(v)
+		if err != nil {
+			return nil, false, fmt.Errorf("[I] %w", err)
+		}
+		diffs = append(diffs, diff)
+		if len(diffs) >= limit+1 {
+			break
+		}
+	}
+	if err := it.Err(); err != nil {
+		return nil, false, err
+	}
+	hasMore := false
+	if len(diffs) > limit {
+		hasMore = true
+		diffs = diffs[:limit]
+	}
+	return diffs, hasMore, nil
+}
+
+// MergePreview returns the changes merging sourceRef into destinationBranch would apply, including conflicts, without
+// merging. It fails like Merge with ErrDirtyBranch when the destination has uncommitted changes. The preview is
+// computed on the current heads: a commit to either side before merging may change the outcome.
+func (c *Catalog) MergePreview(ctx context.Context, repositoryID string, destinationBranch string, sourceRef string, params DiffParams) (Differences, bool, error) {
+	destination := graveler.BranchID(destinationBranch)
+	source := graveler.Ref(sourceRef)
+	if err := validator.Validate([]validator.ValidateArg{
+		{Name: "repository", Value: repositoryID, Fn: graveler.ValidateRepositoryID},
+		{Name: "destination", Value: destination, Fn: graveler.ValidateBranchID},
+		{Name: "source", Value: source, Fn: graveler.ValidateRef},
+	}); err != nil {
+		return nil, false, err
+	}
+	repository, err := c.getRepository(ctx, repositoryID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	dirty, err := c.hasUncommitted(ctx, repository, destination)
+	if err != nil {
+		return nil, false, err
+	}
+	if dirty {
+		return nil, false, fmt.Errorf("%s: %w", destination, graveler.ErrDirtyBranch)
+	}
+
+	iter, err := c.Store.Compare(ctx, repository, graveler.Ref(destination), source)
+	if err != nil {
+		return nil, false, err
+	}
+	it := NewEntryDiffIterator(iter)
+	defer it.Close()
+	return listDiffHelper(it, params.Prefix, params.Delimiter, params.Limit, params.After)
+}
+
+// MergeConflicts returns the paths that would conflict when merging sourceRef into destinationRef, from a three-way
+// comparison against their merge base. Both can be any ref: uncommitted changes are not checked and nothing is
+// written, so no conflicts means the committed states would merge cleanly.
+func (c *Catalog) MergeConflicts(ctx context.Context, repositoryID, destinationRef, sourceRef string, limit int, after string) (Differences, bool, error) {
+	if limit < 0 || limit > DiffLimitMax {
+		limit = DiffLimitMax
+	}
+	destination := graveler.Ref(destinationRef)
+	source := graveler.Ref(sourceRef)
+	if err := validator.Validate([]validator.ValidateArg{
+		{Name: "repository", Value: repositoryID, Fn: graveler.ValidateRepositoryID},
+		{Name: "destination", Value: destination, Fn: graveler.ValidateRef},
+		{Name: "source", Value: source, Fn: graveler.ValidateRef},
+	}); err != nil {
+		return nil, false, err
+	}
+	repository, err := c.getRepository(ctx, repositoryID)
+	if err != nil {
+		return nil, false, err
+	}
+	it, err := c.Store.Compare(ctx, repository, destination, source)
+	if err != nil {
+		return nil, false, err
+	}
+	defer it.Close()
 
-	diffs := make(Differences, 0)
+	it.SeekGE(graveler.Key(after))
+	conflicts := make(Differences, 0)
 	for it.Next() {
-		v := it.Value()
-		path := string(v.Path)
-
+		d := it.Value()
+		if d.Type != graveler.DiffTypeConflict {
+			continue
+		}
+		path := d.Key.String()
 		if path == after {
 			continue // emulate SeekGT using SeekGE
 		}
-		if !strings.HasPrefix(path, prefix) {
-			break // we only want things that start with prefix, apparently there are none left
-		}
-
-		if delimiter != "" {
-			// common prefix logic goes here.
-			// for every path, after trimming "prefix", take the string upto-and-including the delimiter
-			// if the received path == the entire remainder, add that object as is
-			// if it's just a part of the name, add it as a "common prefix" entry -
-			//   and skip to next record following all those starting with this prefix
-			pathRelativeToPrefix := strings.TrimPrefix(path, prefix)
-			// we want the common prefix and the remainder
-			parts := strings.SplitN(pathRelativeToPrefix, delimiter, commonPrefixSplitParts)
-			if len(parts) == commonPrefixSplitParts {
-				// a common prefix exists!
-				commonPrefix := prefix + parts[0] + delimiter
-				diffs = append(diffs, Difference{
-					DBEntry: NewDBEntryBuilder().CommonLevel(true).Path(commonPrefix).Build(),
-					// We always return "changed" for common prefixes. Seeing if a common prefix is e.g. deleted is O(N)
-					Type: DifferenceTypePrefixChanged,
-				})
-				if len(diffs) >= limit+1 {
-					break // collected enough results
-				}
-
-				// let's keep collecting records. We want the next record that doesn't
-				//   start with this common prefix
-				it.SeekGE(Path(graveler.UpperBoundForPrefix([]byte(commonPrefix))))
-				continue
-			}
-		}
-
-		// got a regular entry
-		diff, err := newDifferenceFromEntryDiff(v)
-		if err != nil {
-			return nil, false, fmt.Errorf("[I] %w", err)
-		}
-		diffs = append(diffs, diff)
-		if len(diffs) >= limit+1 {
+		conflicts = append(conflicts, Difference{
+			DBEntry: NewDBEntryBuilder().Path(path).Build(),
+			Type:    DifferenceTypeConflict,
+		})
+		if len(conflicts) > limit {
 			break
 		}
 	}
@@ -1892,11 +4328,11 @@ func listDiffHelper(it EntryDiffIterator, prefix, delimiter string, limit int, a
 		return nil, false, err
 	}
 	hasMore := false
-	if len(diffs) > limit {
+	if len(conflicts) > limit {
 		hasMore = true
-		diffs = diffs[:limit]
+		conflicts = conflicts[:limit]
 	}
-	return diffs, hasMore, nil
+	return conflicts, hasMore, nil
 }
 
 func (c *Catalog) Merge(ctx context.Context, repositoryID string, destinationBranch string, sourceRef string, committer string, message string, metadata Metadata, strategy string, opts ...graveler.SetOptionsFunc) (string, error) {
@@ -1929,6 +4365,9 @@ func (c *Catalog) Merge(ctx context.Context, repositoryID string, destinationBra
 	if err != nil {
 		return "", err
 	}
+	if err := c.checkMaintenance(repository); err != nil {
+		return "", err
+	}
 
 	commitID, err := c.Store.Merge(ctx, repository, destination, source, commitParams, strategy, opts...)
 	if err != nil {
@@ -1937,6 +4376,149 @@ func (c *Catalog) Merge(ctx context.Context, repositoryID string, destinationBra
 	return commitID.String(), nil
 }
 
+// ApplyMergeOperations applies differences computed against sourceRef (for example a merge preview) onto
+// targetBranch, and commits only the affected paths. Operations are re-validated before anything is written, against
+// sourceRef and against the committed state of targetBranch: added or changed paths must still exist on sourceRef,
+// an added path must be missing from targetBranch and a changed path must exist there, and removed paths must still
+// be missing from sourceRef. When an operation carries a physical address or checksum, the entry it applies to must
+// still match it. Otherwise ErrStaleOperation is returned. Removing a path already missing from targetBranch is a
+// no-op. If staging or committing fails, the paths staged so far are reset.
+func (c *Catalog) ApplyMergeOperations(ctx context.Context, repositoryID, targetBranch string, ops Differences, sourceRef, committer string, opts ...graveler.SetOptionsFunc) (*CommitLog, error) {
+	branchID := graveler.BranchID(targetBranch)
+	source := graveler.Ref(sourceRef)
+	if err := validator.Validate([]validator.ValidateArg{
+		{Name: "repository", Value: repositoryID, Fn: graveler.ValidateRepositoryID},
+		{Name: "branch", Value: branchID, Fn: graveler.ValidateBranchID},
+		{Name: "source", Value: source, Fn: graveler.ValidateRef},
+		{Name: "committer", Value: committer, Fn: validator.ValidateRequiredString},
+	}); err != nil {
+		return nil, err
+	}
+	repository, err := c.getRepository(ctx, repositoryID)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.checkMaintenance(repository); err != nil {
+		return nil, err
+	}
+	sourceCommitID, err := c.dereferenceCommitID(ctx, repository, source)
+	if err != nil {
+		return nil, fmt.Errorf("source ref: %w", err)
+	}
+	targetCommitID, err := c.dereferenceCommitID(ctx, repository, graveler.Ref(branchID))
+	if err != nil {
+		return nil, fmt.Errorf("target branch: %w", err)
+	}
+
+	// validate all operations before staging any of them
+	values := make([]*graveler.Value, 0, len(ops))
+	keys := make([]graveler.Key, 0, len(ops))
+	for _, op := range ops {
+		key := graveler.Key(op.Path)
+		sourceValue, err := c.getValueByCommitID(ctx, repository, sourceCommitID, key)
+		if err != nil {
+			return nil, err
+		}
+		targetValue, err := c.getValueByCommitID(ctx, repository, targetCommitID, key)
+		if err != nil {
+			return nil, err
+		}
+		switch op.Type {
+		case DifferenceTypeAdded, DifferenceTypeChanged:
+			if sourceValue == nil {
+				return nil, fmt.Errorf("%s missing from source: %w", op.Path, ErrStaleOperation)
+			}
+			if err := checkDifferenceValue(op, sourceValue, "source"); err != nil {
+				return nil, err
+			}
+			if op.Type == DifferenceTypeAdded && targetValue != nil {
+				return nil, fmt.Errorf("%s exists on target: %w", op.Path, ErrStaleOperation)
+			}
+			if op.Type == DifferenceTypeChanged && targetValue == nil {
+				return nil, fmt.Errorf("%s missing from target: %w", op.Path, ErrStaleOperation)
+			}
+			values = append(values, sourceValue)
+		case DifferenceTypeRemoved:
+			if sourceValue != nil {
+				return nil, fmt.Errorf("%s exists on source: %w", op.Path, ErrStaleOperation)
+			}
+			if targetValue == nil {
+				continue
+			}
+			if err := checkDifferenceValue(op, targetValue, "target"); err != nil {
+				return nil, err
+			}
+			values = append(values, nil)
+		default:
+			return nil, fmt.Errorf("%s: %w", op, ErrUnknownDiffType)
+		}
+		keys = append(keys, key)
+	}
+
+	staged := 0
+	for i, key := range keys {
+		if values[i] == nil {
+			err = c.Store.Delete(ctx, repository, branchID, key, opts...)
+		} else {
+			err = c.Store.Set(ctx, repository, branchID, key, *values[i], opts...)
+		}
+		if err != nil {
+			return nil, c.resetStagedKeys(ctx, repository, branchID, keys[:staged], err, opts...)
+		}
+		staged++
+	}
+
+	commitID, err := c.Store.CommitKeys(ctx, repository, branchID, keys, graveler.CommitParams{
+		Committer: committer,
+		Message:   fmt.Sprintf("Apply changes from '%s' into '%s'", source, branchID),
+	}, opts...)
+	if err != nil {
+		return nil, c.resetStagedKeys(ctx, repository, branchID, keys, err, opts...)
+	}
+	commit, err := c.Store.GetCommit(ctx, repository, commitID)
+	if err != nil {
+		return nil, err
+	}
+	return CommitRecordToLog(&graveler.CommitRecord{CommitID: commitID, Commit: commit}), nil
+}
+
+// getValueByCommitID returns the value of key committed on commitID, or nil when the key is missing
+func (c *Catalog) getValueByCommitID(ctx context.Context, repository *graveler.RepositoryRecord, commitID graveler.CommitID, key graveler.Key) (*graveler.Value, error) {
+	value, err := c.Store.GetByCommitID(ctx, repository, commitID, key)
+	if errors.Is(err, graveler.ErrNotFound) {
+		return nil, nil
+	}
+	return value, err
+}
+
+// checkDifferenceValue returns ErrStaleOperation when the physical address or checksum carried by diff, when set,
+// does not match the entry of value found on side
+func checkDifferenceValue(diff Difference, value *graveler.Value, side string) error {
+	if diff.PhysicalAddress == "" && diff.Checksum == "" {
+		return nil
+	}
+	entry, err := ValueToEntry(value)
+	if err != nil {
+		return err
+	}
+	if (diff.PhysicalAddress != "" && diff.PhysicalAddress != entry.Address) || (diff.Checksum != "" && diff.Checksum != entry.ETag) {
+		return fmt.Errorf("%s changed on %s: %w", diff.Path, side, ErrStaleOperation)
+	}
+	return nil
+}
+
+// resetStagedKeys resets keys staged on branchID after cause failed the operation, and returns cause joined with any
+// reset failure
+func (c *Catalog) resetStagedKeys(ctx context.Context, repository *graveler.RepositoryRecord, branchID graveler.BranchID, keys []graveler.Key, cause error, opts ...graveler.SetOptionsFunc) error {
+	errs := []error{cause}
+	for _, key := range keys {
+		if err := c.Store.ResetKey(ctx, repository, branchID, key, opts...); err != nil {
+			errs = append(errs, fmt.Errorf("reset %s: %w", key, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
 func (c *Catalog) FindMergeBase(ctx context.Context, repositoryID string, destinationRef string, sourceRef string) (string, string, string, error) {
 	destination := graveler.Ref(destinationRef)
 	source := graveler.Ref(sourceRef)
@@ -1960,6 +4542,27 @@ func (c *Catalog) FindMergeBase(ctx context.Context, repositoryID string, destin
 	return fromCommit.CommitID.String(), toCommit.CommitID.String(), c.addressProvider.ContentAddress(baseCommit), nil
 }
 
+// IsAncestor reports whether the commit of ancestorRef is reachable from the commit of descendantRef, e.g. to decide
+// whether descendantRef can be fast-forwarded from ancestorRef. A commit is its own ancestor.
+// It uses the same check as fast-forward merges, and fails with ErrHistoryTooLong when the walk is too long.
+func (c *Catalog) IsAncestor(ctx context.Context, repositoryID string, ancestorRef string, descendantRef string) (bool, error) {
+	ancestor := graveler.Ref(ancestorRef)
+	descendant := graveler.Ref(descendantRef)
+	if err := validator.Validate([]validator.ValidateArg{
+		{Name: "repository", Value: repositoryID, Fn: graveler.ValidateRepositoryID},
+		{Name: "ancestor", Value: ancestor, Fn: graveler.ValidateRef},
+		{Name: "descendant", Value: descendant, Fn: graveler.ValidateRef},
+	}); err != nil {
+		return false, err
+	}
+	repository, err := c.getRepository(ctx, repositoryID)
+	if err != nil {
+		return false, err
+	}
+
+	return c.Store.IsAncestor(ctx, repository, ancestor, descendant)
+}
+
 func (c *Catalog) DumpRepositorySubmit(ctx context.Context, repositoryID string) (string, error) {
 	repository, err := c.getRepository(ctx, repositoryID)
 	if err != nil {
@@ -2036,6 +4639,9 @@ func (c *Catalog) RestoreRepositorySubmit(ctx context.Context, repositoryID stri
 	if err != nil {
 		return "", err
 	}
+	if err := c.checkMaintenance(repository); err != nil {
+		return "", err
+	}
 
 	// verify bare repository - no commits
 	_, _, err = c.ListCommits(ctx, repository.RepositoryID.String(), repository.DefaultBranchID.String(), LogParams{
@@ -2215,6 +4821,9 @@ func (c *Catalog) LoadCommits(ctx context.Context, repositoryID, commitsMetaRang
 	if err != nil {
 		return err
 	}
+	if err := c.checkMaintenance(repository); err != nil {
+		return err
+	}
 	return c.Store.LoadCommits(ctx, repository, graveler.MetaRangeID(commitsMetaRangeID), opts...)
 }
 
@@ -2223,6 +4832,9 @@ func (c *Catalog) LoadBranches(ctx context.Context, repositoryID, branchesMetaRa
 	if err != nil {
 		return err
 	}
+	if err := c.checkMaintenance(repository); err != nil {
+		return err
+	}
 	return c.Store.LoadBranches(ctx, repository, graveler.MetaRangeID(branchesMetaRangeID), opts...)
 }
 
@@ -2231,6 +4843,9 @@ func (c *Catalog) LoadTags(ctx context.Context, repositoryID, tagsMetaRangeID st
 	if err != nil {
 		return err
 	}
+	if err := c.checkMaintenance(repository); err != nil {
+		return err
+	}
 	return c.Store.LoadTags(ctx, repository, graveler.MetaRangeID(tagsMetaRangeID), opts...)
 }
 
@@ -2363,6 +4978,9 @@ func (c *Catalog) Import(ctx context.Context, repositoryID, branchID string, par
 	if err != nil {
 		return "", err
 	}
+	if err := c.checkMaintenance(repository); err != nil {
+		return "", err
+	}
 
 	_, err = c.Store.GetBranch(ctx, repository, graveler.BranchID(branchID))
 	if err != nil {
@@ -2435,6 +5053,9 @@ func (c *Catalog) WriteRange(ctx context.Context, repositoryID string, params Wr
 	if err != nil {
 		return nil, nil, err
 	}
+	if err := c.checkMaintenance(repository); err != nil {
+		return nil, nil, err
+	}
 
 	// TODO (niro): Need to handle this at some point (use adapter GetWalker)
 	walker, err := c.walkerFactory.GetWalker(ctx, store.WalkerOptions{StorageURI: params.SourceURI, SkipOutOfOrder: true})
@@ -2487,6 +5108,9 @@ func (c *Catalog) WriteMetaRange(ctx context.Context, repositoryID string, range
 	if err != nil {
 		return nil, err
 	}
+	if err := c.checkMaintenance(repository); err != nil {
+		return nil, err
+	}
 	return c.Store.WriteMetaRange(ctx, repository, ranges, opts...)
 }
 
@@ -2503,6 +5127,9 @@ func (c *Catalog) SetGarbageCollectionRules(ctx context.Context, repositoryID st
 	if err != nil {
 		return err
 	}
+	if err := c.checkMaintenance(repository); err != nil {
+		return err
+	}
 	if repository.ReadOnly {
 		return graveler.ErrReadOnlyRepository
 	}
@@ -2523,6 +5150,9 @@ func (c *Catalog) SetBranchProtectionRules(ctx context.Context, repositoryID str
 	if err != nil {
 		return err
 	}
+	if err := c.checkMaintenance(repository); err != nil {
+		return err
+	}
 	if repository.ReadOnly {
 		return graveler.ErrReadOnlyRepository
 	}
@@ -2715,6 +5345,177 @@ func (c *Catalog) CopyEntry(ctx context.Context, srcRepository, srcRef, srcPath,
 	return &dstEntry, nil
 }
 
+// PhysicalAddressExists reports whether an object exists at physicalAddress in the object store, without reading
+// it. A relative address is resolved against the repository storage namespace. It lets clients check whether data
+// was already uploaded before uploading it again.
+func (c *Catalog) PhysicalAddressExists(ctx context.Context, repositoryID string, physicalAddress string, addressType AddressType) (bool, error) {
+	if err := validator.Validate([]validator.ValidateArg{
+		{Name: "repository", Value: repositoryID, Fn: graveler.ValidateRepositoryID},
+		{Name: "physical address", Value: physicalAddress, Fn: validator.ValidateRequiredString},
+	}); err != nil {
+		return false, err
+	}
+	repository, err := c.getRepository(ctx, repositoryID)
+	if err != nil {
+		return false, err
+	}
+	return c.BlockAdapter.Exists(ctx, block.ObjectPointer{
+		StorageNamespace: repository.StorageNamespace.String(),
+		IdentifierType:   addressType.ToIdentifierType(),
+		Identifier:       physicalAddress,
+	})
+}
+
+// VerifyEntry reads the object of the entry at path on reference and reports whether its size and MD5 match the entry
+// size and checksum, to detect objects corrupted or replaced on the object store.
+// Only checksums that are the MD5 of the content can be verified: it fails with ErrChecksumNotVerifiable for multipart
+// upload checksums and for entries larger than VerifyEntryMaxSize. Verification reads the whole object, at most one
+// byte past the entry size, and stops when ctx is done.
+func (c *Catalog) VerifyEntry(ctx context.Context, repositoryID, reference, path string) (bool, error) {
+	entry, err := c.GetEntry(ctx, repositoryID, reference, path, GetEntryParams{})
+	if err != nil {
+		return false, err
+	}
+	if strings.Contains(entry.Checksum, "-") {
+		return false, fmt.Errorf("%s checksum %s: %w", path, entry.Checksum, ErrChecksumNotVerifiable)
+	}
+	if entry.Size > VerifyEntryMaxSize {
+		return false, fmt.Errorf("%s size %d larger than %d: %w", path, entry.Size, int64(VerifyEntryMaxSize), ErrChecksumNotVerifiable)
+	}
+	repository, err := c.getRepository(ctx, repositoryID)
+	if err != nil {
+		return false, err
+	}
+	reader, err := c.BlockAdapter.Get(ctx, block.ObjectPointer{
+		StorageNamespace: repository.StorageNamespace.String(),
+		IdentifierType:   entry.AddressType.ToIdentifierType(),
+		Identifier:       entry.PhysicalAddress,
+	})
+	if err != nil {
+		return false, err
+	}
+	defer func() { _ = reader.Close() }()
+
+	// an object longer than the entry is a mismatch, reading one more byte is enough to tell
+	limited := io.LimitReader(&contextReader{ctx: ctx, r: reader}, entry.Size+1)
+	hashingReader := block.NewHashingReader(limited, block.HashFunctionMD5)
+	if _, err := io.Copy(io.Discard, hashingReader); err != nil {
+		return false, err
+	}
+	if hashingReader.CopiedSize != entry.Size {
+		return false, nil
+	}
+	return hex.EncodeToString(hashingReader.Md5.Sum(nil)) == entry.Checksum, nil
+}
+
+// contextReader reads from r until ctx is done
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (r *contextReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return r.r.Read(p)
+}
+
+// ShallowCopyEntry creates an entry at destPath on branch that points to the same physical object as srcPath.
+// The source is read from the branch, including its staged changes, and no data is copied. Garbage collection
+// keeps the object for as long as any committed or staged entry still references its address.
+func (c *Catalog) ShallowCopyEntry(ctx context.Context, repositoryID, branch, srcPath, destPath string, opts ...graveler.SetOptionsFunc) (*DBEntry, error) {
+	branchID := graveler.BranchID(branch)
+	if err := validator.Validate([]validator.ValidateArg{
+		{Name: "repository", Value: repositoryID, Fn: graveler.ValidateRepositoryID},
+		{Name: "branch", Value: branchID, Fn: graveler.ValidateBranchID},
+		{Name: "source path", Value: Path(srcPath), Fn: ValidatePath},
+		{Name: "destination path", Value: Path(destPath), Fn: ValidatePath},
+	}); err != nil {
+		return nil, err
+	}
+	repository, err := c.getRepository(ctx, repositoryID)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.checkMaintenance(repository); err != nil {
+		return nil, err
+	}
+	val, err := c.Store.Get(ctx, repository, graveler.Ref(branchID), graveler.Key(srcPath))
+	if err != nil {
+		return nil, err
+	}
+	ent, err := ValueToEntry(val)
+	if err != nil {
+		return nil, err
+	}
+	ent.LastModified = timestamppb.Now()
+	value, err := EntryToValue(ent)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.Store.Set(ctx, repository, branchID, graveler.Key(destPath), *value, opts...); err != nil {
+		return nil, err
+	}
+	dstEntry := newCatalogEntryFromEntry(false, destPath, ent)
+	return &dstEntry, nil
+}
+
+// MoveEntry renames srcPath to destPath on branch. The destination points to the source's physical object and
+// is overwritten if it exists. Staging has no multi-key transactions, so the move is done as a set followed by a
+// delete. If the source cannot be deleted, the destination is restored to its previous state.
+func (c *Catalog) MoveEntry(ctx context.Context, repositoryID, branch, srcPath, destPath string, opts ...graveler.SetOptionsFunc) (*DBEntry, error) {
+	branchID := graveler.BranchID(branch)
+	if err := validator.Validate([]validator.ValidateArg{
+		{Name: "repository", Value: repositoryID, Fn: graveler.ValidateRepositoryID},
+		{Name: "branch", Value: branchID, Fn: graveler.ValidateBranchID},
+		{Name: "source path", Value: Path(srcPath), Fn: ValidatePath},
+		{Name: "destination path", Value: Path(destPath), Fn: ValidatePath},
+	}); err != nil {
+		return nil, err
+	}
+	if srcPath == destPath {
+		return nil, fmt.Errorf("destination path same as source: %w", graveler.ErrInvalidValue)
+	}
+	repository, err := c.getRepository(ctx, repositoryID)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.checkMaintenance(repository); err != nil {
+		return nil, err
+	}
+	ref := graveler.Ref(branchID)
+	srcValue, err := c.Store.Get(ctx, repository, ref, graveler.Key(srcPath))
+	if err != nil {
+		return nil, err
+	}
+	prevDestValue, err := c.Store.Get(ctx, repository, ref, graveler.Key(destPath))
+	if err != nil && !errors.Is(err, graveler.ErrNotFound) {
+		return nil, err
+	}
+	ent, err := ValueToEntry(srcValue)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.Store.Set(ctx, repository, branchID, graveler.Key(destPath), *srcValue, opts...); err != nil {
+		return nil, err
+	}
+	if err := c.Store.Delete(ctx, repository, branchID, graveler.Key(srcPath), opts...); err != nil {
+		var restoreErr error
+		if prevDestValue != nil {
+			restoreErr = c.Store.Set(ctx, repository, branchID, graveler.Key(destPath), *prevDestValue, opts...)
+		} else {
+			restoreErr = c.Store.Delete(ctx, repository, branchID, graveler.Key(destPath), opts...)
+		}
+		if restoreErr != nil {
+			c.log(ctx).WithError(restoreErr).WithField("path", destPath).Error("Failed to restore destination of failed move")
+		}
+		return nil, err
+	}
+	dstEntry := newCatalogEntryFromEntry(false, destPath, ent)
+	return &dstEntry, nil
+}
+
 func (c *Catalog) DeleteExpiredImports(ctx context.Context) {
 	repos, err := c.listRepositoriesHelper(ctx)
 	if err != nil {
@@ -0,0 +1,125 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/alitto/pond"
+	"github.com/stretchr/testify/require"
+	"github.com/treeverse/lakefs/pkg/graveler"
+	gUtils "github.com/treeverse/lakefs/pkg/graveler/testutil"
+)
+
+func TestCatalog_ListCommitsFilters(t *testing.T) {
+	day := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	commit := func(id string, parents []string, committer, message string, days int, metadata graveler.Metadata) *graveler.CommitRecord {
+		commitParents := make(graveler.CommitParents, 0, len(parents))
+		for _, p := range parents {
+			commitParents = append(commitParents, graveler.CommitID(p))
+		}
+		return &graveler.CommitRecord{
+			CommitID: graveler.CommitID(id),
+			Commit: &graveler.Commit{
+				Parents:      commitParents,
+				Committer:    committer,
+				Message:      message,
+				CreationDate: day.AddDate(0, 0, days),
+				Metadata:     metadata,
+			},
+		}
+	}
+	// log order, c5 merges x1 into c4
+	commits := []*graveler.CommitRecord{
+		commit("c5", []string{"c4", "x1"}, "alice", "merge feature", 5, graveler.Metadata{"ticket": "A"}),
+		commit("c4", []string{"c3"}, "bob", "fix bug", 4, graveler.Metadata{"ticket": "B"}),
+		commit("c3", []string{"c2"}, "alice", "add data", 3, graveler.Metadata{"ticket": "A"}),
+		commit("c2", []string{"c1"}, "bob", "fix typo", 2, nil),
+		commit("c1", []string{"c0"}, "alice", "initial", 1, nil),
+	}
+	gravelerMock := &FakeGraveler{
+		CommitIteratorFactory: func() graveler.CommitIterator {
+			return gUtils.NewFakeCommitIterator(commits)
+		},
+		// every commit changes "data/a" compared to its first parent
+		DiffIteratorFactory: func() graveler.DiffIterator {
+			return gUtils.NewDiffIter([]graveler.Diff{{Type: graveler.DiffTypeChanged, Key: graveler.Key("data/a")}})
+		},
+	}
+	workPool := pond.New(2, 8)
+	defer workPool.StopAndWait()
+	c := &Catalog{
+		Store:    gravelerMock,
+		workPool: workPool,
+	}
+	until := day.AddDate(0, 0, 3)
+
+	tests := []struct {
+		name   string
+		params LogParams
+		// want holds the log without paths, wantPaths the log of the paths under "data/" where merge commits are
+		// skipped unless listing merge commits only
+		want      []string
+		wantPaths []string
+	}{
+		{name: "merges only", params: LogParams{MergesOnly: true}, want: []string{"c5"}, wantPaths: []string{"c5"}},
+		{name: "metadata", params: LogParams{Metadata: map[string]string{"ticket": "A"}}, want: []string{"c5", "c3"}, wantPaths: []string{"c3"}},
+		{name: "committer", params: LogParams{Committer: "bob"}, want: []string{"c4", "c2"}, wantPaths: []string{"c4", "c2"}},
+		{name: "until", params: LogParams{Until: &until}, want: []string{"c3", "c2", "c1"}, wantPaths: []string{"c3", "c2", "c1"}},
+		{name: "message contains", params: LogParams{MessageContains: "fix"}, want: []string{"c4", "c2"}, wantPaths: []string{"c4", "c2"}},
+		{name: "combined", params: LogParams{Committer: "alice", MessageContains: "a"}, want: []string{"c5", "c3", "c1"}, wantPaths: []string{"c3", "c1"}},
+		{name: "stop at filtered out commit", params: LogParams{Committer: "alice", StopAt: "c2"}, want: []string{"c5", "c3"}, wantPaths: []string{"c3"}},
+	}
+	for _, tt := range tests {
+		for _, withPaths := range []bool{false, true} {
+			t.Run(fmt.Sprintf("%s paths=%t", tt.name, withPaths), func(t *testing.T) {
+				params := tt.params
+				params.Amount = 10
+				want := tt.want
+				if withPaths {
+					params.PathList = []PathRecord{{Path: "data/", IsPrefix: true}}
+					want = tt.wantPaths
+				}
+				got, hasMore, err := c.ListCommits(context.Background(), "repo", "main", params)
+				require.NoError(t, err)
+				require.False(t, hasMore)
+				refs := make([]string, 0, len(got))
+				for _, commitLog := range got {
+					refs = append(refs, commitLog.Reference)
+				}
+				require.Equal(t, want, refs)
+			})
+		}
+	}
+
+	t.Run("paginate", func(t *testing.T) {
+		got, hasMore, err := c.ListCommits(context.Background(), "repo", "main", LogParams{Committer: "alice", Amount: 1})
+		require.NoError(t, err)
+		require.True(t, hasMore)
+		require.Len(t, got, 1)
+		require.Equal(t, "c5", got[0].Reference)
+
+		got, hasMore, err = c.ListCommits(context.Background(), "repo", "main", LogParams{Committer: "alice", Amount: 1, FromReference: "c5"})
+		require.NoError(t, err)
+		require.True(t, hasMore)
+		require.Len(t, got, 1)
+		require.Equal(t, "c3", got[0].Reference)
+	})
+
+	t.Run("bounded scan", func(t *testing.T) {
+		long := make([]*graveler.CommitRecord, 0, LogFilterMaxCommits+1)
+		for i := 0; i <= LogFilterMaxCommits; i++ {
+			long = append(long, commit(fmt.Sprintf("c%d", i), []string{"p"}, "bob", "", 0, nil))
+		}
+		longCatalog := &Catalog{
+			Store: &FakeGraveler{
+				CommitIteratorFactory: func() graveler.CommitIterator {
+					return gUtils.NewFakeCommitIterator(long)
+				},
+			},
+		}
+		_, _, err := longCatalog.ListCommits(context.Background(), "repo", "main", LogParams{Committer: "alice", Amount: 10})
+		require.ErrorIs(t, err, ErrHistoryTooLong)
+	})
+}
@@ -3,10 +3,14 @@ package catalog_test
 import (
 	"bytes"
 	"context"
+	"crypto/md5" //nolint:gosec
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
 	"net/url"
 	"sort"
+	"strings"
 	"testing"
 	"time"
 
@@ -19,6 +23,7 @@ import (
 	"github.com/treeverse/lakefs/pkg/graveler"
 	gUtils "github.com/treeverse/lakefs/pkg/graveler/testutil"
 	"github.com/treeverse/lakefs/pkg/testutil"
+	"github.com/treeverse/lakefs/pkg/validator"
 	"github.com/xitongsys/parquet-go-source/buffer"
 	"github.com/xitongsys/parquet-go/reader"
 	"google.golang.org/protobuf/proto"
@@ -168,6 +173,92 @@ func TestCatalog_ListRepositories(t *testing.T) {
 	}
 }
 
+func TestCatalog_GetRepositoryWithHead_NoDefaultBranch(t *testing.T) {
+	// the fake repository has no default branch set, so it is missing from the branches
+	gravelerMock := &catalog.FakeGraveler{
+		BranchIteratorFactory: gUtils.NewFakeBranchIteratorFactory([]*graveler.BranchRecord{
+			{BranchID: "main", Branch: &graveler.Branch{CommitID: "c1"}},
+		}),
+	}
+	c := &catalog.Catalog{
+		Store: gravelerMock,
+	}
+	repo, branch, err := c.GetRepositoryWithHead(context.Background(), "repo")
+	require.NoError(t, err)
+	require.Equal(t, "repo", repo.Name)
+	require.Nil(t, branch)
+}
+
+func TestCatalog_SetRepositoryMaintenance(t *testing.T) {
+	ctx := context.Background()
+	gravelerMock := &catalog.FakeGraveler{
+		KeyValue: map[string]*graveler.Value{},
+	}
+	c := &catalog.Catalog{
+		Store: gravelerMock,
+	}
+
+	err := c.SetRepositoryMaintenance(ctx, "repo", true, "")
+	require.ErrorIs(t, err, graveler.ErrInvalidValue)
+
+	require.NoError(t, c.SetRepositoryMaintenance(ctx, "repo", true, "migration"))
+	err = c.DeleteEntries(ctx, "repo", "main", []string{"a"})
+	require.ErrorIs(t, err, graveler.ErrRepositoryInMaintenance)
+	require.ErrorContains(t, err, "migration")
+
+	require.NoError(t, c.SetRepositoryMaintenance(ctx, "repo", false, ""))
+	require.NoError(t, c.DeleteEntries(ctx, "repo", "main", []string{"a"}))
+}
+
+func TestCatalog_ReposByStorageNamespace(t *testing.T) {
+	now := time.Now()
+	gravelerData := []*graveler.RepositoryRecord{
+		{RepositoryID: "repo1", Repository: &graveler.Repository{StorageNamespace: "s3://bucket/shared", CreationDate: now, DefaultBranchID: "main1"}},
+		{RepositoryID: "repo2", Repository: &graveler.Repository{StorageNamespace: "s3://bucket/other", CreationDate: now, DefaultBranchID: "main2"}},
+		{RepositoryID: "repo3", Repository: &graveler.Repository{StorageNamespace: "s3://bucket/shared/", CreationDate: now, DefaultBranchID: "main3"}},
+	}
+	tests := []struct {
+		name      string
+		namespace string
+		want      []*catalog.Repository
+	}{
+		{
+			name:      "shared",
+			namespace: "s3://bucket/shared",
+			want: []*catalog.Repository{
+				{Name: "repo1", StorageNamespace: "s3://bucket/shared", DefaultBranch: "main1", CreationDate: now},
+				{Name: "repo3", StorageNamespace: "s3://bucket/shared/", DefaultBranch: "main3", CreationDate: now},
+			},
+		},
+		{
+			name:      "single",
+			namespace: "s3://bucket/other/",
+			want: []*catalog.Repository{
+				{Name: "repo2", StorageNamespace: "s3://bucket/other", DefaultBranch: "main2", CreationDate: now},
+			},
+		},
+		{
+			name:      "none",
+			namespace: "s3://bucket/missing",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gravelerMock := &catalog.FakeGraveler{
+				RepositoryIteratorFactory: catalog.NewFakeRepositoryIteratorFactory(gravelerData),
+			}
+			c := &catalog.Catalog{
+				Store: gravelerMock,
+			}
+			got, err := c.ReposByStorageNamespace(context.Background(), tt.namespace)
+			require.NoError(t, err)
+			if diff := deep.Equal(got, tt.want); diff != nil {
+				t.Error("ReposByStorageNamespace diff found:", diff)
+			}
+		})
+	}
+}
+
 func TestCatalog_BranchExists(t *testing.T) {
 	// prepare branch data
 	gravelerData := []*graveler.BranchRecord{
@@ -205,6 +296,35 @@ func TestCatalog_BranchExists(t *testing.T) {
 	}
 }
 
+func TestCatalog_ListBranchesAtCommit(t *testing.T) {
+	commit1 := graveler.CommitID(strings.Repeat("1", 64))
+	commit2 := graveler.CommitID(strings.Repeat("2", 64))
+	c := &catalog.Catalog{
+		Store: &catalog.FakeGraveler{
+			BranchIteratorFactory: gUtils.NewFakeBranchIteratorFactory([]*graveler.BranchRecord{
+				{BranchID: "branch1", Branch: &graveler.Branch{CommitID: commit1}},
+				{BranchID: "branch2", Branch: &graveler.Branch{CommitID: commit2}},
+				{BranchID: "branch3", Branch: &graveler.Branch{CommitID: commit1}},
+			}),
+		},
+	}
+	ctx := context.Background()
+
+	branches, err := c.ListBranchesAtCommit(ctx, "repo", commit1.String())
+	require.NoError(t, err)
+	require.Equal(t, []*catalog.Branch{
+		{Name: "branch1", Reference: commit1.String()},
+		{Name: "branch3", Reference: commit1.String()},
+	}, branches)
+
+	branches, err = c.ListBranchesAtCommit(ctx, "repo", strings.Repeat("3", 64))
+	require.NoError(t, err)
+	require.Empty(t, branches)
+
+	_, err = c.ListBranchesAtCommit(ctx, "repo", "main")
+	require.ErrorIs(t, err, graveler.ErrInvalidCommitID)
+}
+
 func TestCatalog_ListBranches(t *testing.T) {
 	// prepare branch data
 	gravelerData := []*graveler.BranchRecord{
@@ -306,6 +426,143 @@ func TestCatalog_ListBranches(t *testing.T) {
 	}
 }
 
+func TestCatalog_CreateBranchWithUncommitted(t *testing.T) {
+	value := func(address string) *graveler.Value {
+		return catalog.MustEntryToValue(&catalog.Entry{Address: address})
+	}
+	// uncommitted changes on main: "a" added, "b" overwritten and "c" deleted
+	diffs := []graveler.Diff{
+		{Type: graveler.DiffTypeAdded, Key: graveler.Key("a"), Value: value("data/a")},
+		{Type: graveler.DiffTypeChanged, Key: graveler.Key("b"), Value: value("data/b2"), LeftIdentity: []byte("b1")},
+		{Type: graveler.DiffTypeRemoved, Key: graveler.Key("c"), Value: value("data/c"), LeftIdentity: []byte("c")},
+	}
+	gravelerMock := &catalog.FakeGraveler{
+		// committed entries as seen from the new branch
+		KeyValue: map[string]*graveler.Value{
+			"repo/feature/b": value("data/b1"),
+			"repo/feature/c": value("data/c"),
+		},
+		BranchIteratorFactory: gUtils.NewFakeBranchIteratorFactory([]*graveler.BranchRecord{
+			{BranchID: "main", Branch: &graveler.Branch{CommitID: "c1"}},
+		}),
+		CommitIteratorFactory: func() graveler.CommitIterator {
+			return gUtils.NewFakeCommitIterator([]*graveler.CommitRecord{
+				{CommitID: "c1", Commit: &graveler.Commit{Message: "first"}},
+			})
+		},
+		TagIteratorFactory: catalog.NewFakeTagIteratorFactory(nil),
+		DiffIteratorFactory: func() graveler.DiffIterator {
+			return gUtils.NewDiffIter(diffs)
+		},
+	}
+	c := &catalog.Catalog{
+		Store: gravelerMock,
+	}
+	commitLog, err := c.CreateBranchWithUncommitted(context.Background(), "repo", "feature", "main")
+	require.NoError(t, err)
+	require.Equal(t, "c1", commitLog.Reference)
+	require.Equal(t, map[string]*graveler.Value{
+		"repo/feature/a": value("data/a"),
+		"repo/feature/b": value("data/b2"),
+	}, gravelerMock.KeyValue)
+}
+
+func TestCatalog_DeleteBranches(t *testing.T) {
+	value := catalog.MustEntryToValue(&catalog.Entry{Address: "data"})
+	gravelerMock := &catalog.FakeGraveler{
+		KeyValue: map[string]*graveler.Value{
+			"repo/main/a":  value,
+			"repo/exp1/a":  value,
+			"repo/exp2/a":  value,
+			"repo/other/a": value,
+		},
+		BranchIteratorFactory: gUtils.NewFakeBranchIteratorFactory([]*graveler.BranchRecord{
+			{BranchID: "exp1", Branch: &graveler.Branch{CommitID: "c1"}},
+			{BranchID: "exp2", Branch: &graveler.Branch{CommitID: "c1"}},
+			{BranchID: "main", Branch: &graveler.Branch{CommitID: "c1"}},
+			{BranchID: "other", Branch: &graveler.Branch{CommitID: "c1"}},
+		}),
+		DefaultBranchID: "main",
+	}
+	c := &catalog.Catalog{
+		Store: gravelerMock,
+	}
+	notDeleted, err := c.DeleteBranches(context.Background(), "repo", []string{"exp1", "missing1", "main", "exp2", "missing2"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"missing1", "main", "missing2"}, notDeleted)
+	require.Equal(t, map[string]*graveler.Value{
+		"repo/main/a":  value,
+		"repo/other/a": value,
+	}, gravelerMock.KeyValue)
+}
+
+func TestCatalog_UpdateBranchHead(t *testing.T) {
+	commit1 := strings.Repeat("1", 64)
+	commit2 := strings.Repeat("2", 64)
+	gravelerMock := &catalog.FakeGraveler{
+		CommitIteratorFactory: func() graveler.CommitIterator {
+			return gUtils.NewFakeCommitIterator([]*graveler.CommitRecord{
+				{CommitID: graveler.CommitID(commit1), Commit: &graveler.Commit{}},
+				{CommitID: graveler.CommitID(commit2), Commit: &graveler.Commit{}},
+			})
+		},
+	}
+	c := &catalog.Catalog{
+		Store: gravelerMock,
+	}
+	ctx := context.Background()
+	tests := []struct {
+		name     string
+		expected string
+		new      string
+		wantErr  error
+	}{
+		{name: "commits", expected: commit1, new: commit2},
+		{name: "expected branch", expected: "main", new: commit2, wantErr: graveler.ErrInvalidCommitID},
+		{name: "new branch", expected: commit1, new: "main", wantErr: graveler.ErrInvalidCommitID},
+		{name: "new relative ref", expected: commit1, new: commit2 + "~1", wantErr: graveler.ErrInvalidCommitID},
+		{name: "unknown commit", expected: commit1, new: strings.Repeat("3", 64), wantErr: graveler.ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := c.UpdateBranchHead(ctx, "repo", "main", tt.expected, tt.new)
+			if tt.wantErr == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCatalog_ListStaleBranches(t *testing.T) {
+	day := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	gravelerMock := &catalog.FakeGraveler{
+		BranchIteratorFactory: gUtils.NewFakeBranchIteratorFactory([]*graveler.BranchRecord{
+			{BranchID: "experiment", Branch: &graveler.Branch{CommitID: "c1"}},
+			{BranchID: "feature", Branch: &graveler.Branch{CommitID: "c2"}},
+			{BranchID: "main", Branch: &graveler.Branch{CommitID: "c3"}},
+			{BranchID: "old", Branch: &graveler.Branch{CommitID: "c1"}},
+		}),
+		CommitIteratorFactory: func() graveler.CommitIterator {
+			return gUtils.NewFakeCommitIterator([]*graveler.CommitRecord{
+				{CommitID: "c3", Commit: &graveler.Commit{CreationDate: day.Add(48 * time.Hour)}},
+				{CommitID: "c2", Commit: &graveler.Commit{CreationDate: day.Add(24 * time.Hour)}},
+				{CommitID: "c1", Commit: &graveler.Commit{CreationDate: day}},
+			})
+		},
+	}
+	c := &catalog.Catalog{
+		Store: gravelerMock,
+	}
+	stale, err := c.ListStaleBranches(context.Background(), "repo", day.Add(24*time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, []*catalog.BranchActivity{
+		{Branch: catalog.Branch{Name: "experiment", Reference: "c1"}, LastCommitDate: day},
+		{Branch: catalog.Branch{Name: "old", Reference: "c1"}, LastCommitDate: day},
+	}, stale)
+}
+
 func TestCatalog_ListTags(t *testing.T) {
 	gravelerData := []*graveler.TagRecord{
 		{TagID: "t1", CommitID: "c1"},
@@ -525,6 +782,1509 @@ func TestCatalog_ListEntries(t *testing.T) {
 	}
 }
 
+func TestCatalog_ListObjectsWithCommit(t *testing.T) {
+	var gravelerData []*graveler.ValueRecord
+	for _, key := range []string{"a", "b", "c", "d/1"} {
+		gravelerData = append(gravelerData, &graveler.ValueRecord{
+			Key:   graveler.Key(key),
+			Value: catalog.MustEntryToValue(&catalog.Entry{Address: "data/" + key}),
+		})
+	}
+	// first-parent history c3 -> c2 -> c1, "c" and "d/1" were added by the root commit
+	diffs := map[string][]graveler.Diff{
+		"c2..c3": {{Type: graveler.DiffTypeChanged, Key: graveler.Key("b")}},
+		"c1..c2": {
+			{Type: graveler.DiffTypeAdded, Key: graveler.Key("a")},
+			{Type: graveler.DiffTypeAdded, Key: graveler.Key("b")},
+		},
+	}
+	gravelerMock := &catalog.FakeGraveler{
+		ListIteratorFactory: catalog.NewFakeValueIteratorFactory(gravelerData),
+		CommitIteratorFactory: func() graveler.CommitIterator {
+			return gUtils.NewFakeCommitIterator([]*graveler.CommitRecord{
+				{CommitID: "c3", Commit: &graveler.Commit{Parents: graveler.CommitParents{"c2"}}},
+				{CommitID: "c2", Commit: &graveler.Commit{Parents: graveler.CommitParents{"c1"}}},
+				{CommitID: "c1", Commit: &graveler.Commit{}},
+			})
+		},
+		RefsDiffIteratorFactory: func(left, right graveler.Ref) graveler.DiffIterator {
+			return gUtils.NewDiffIter(diffs[left.String()+".."+right.String()])
+		},
+	}
+	c := &catalog.Catalog{
+		Store: gravelerMock,
+	}
+	ctx := context.Background()
+	tests := []struct {
+		name        string
+		prefix      string
+		limit       int
+		after       string
+		want        map[string]string
+		wantHasMore bool
+	}{
+		{name: "all", limit: -1, want: map[string]string{"a": "c2", "b": "c3", "c": "c1", "d/1": "c1"}},
+		{name: "first page", limit: 2, want: map[string]string{"a": "c2", "b": "c3"}, wantHasMore: true},
+		{name: "second page", limit: 2, after: "b", want: map[string]string{"c": "c1", "d/1": "c1"}},
+		{name: "prefix", prefix: "d/", limit: -1, want: map[string]string{"d/1": "c1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, hasMore, err := c.ListObjectsWithCommit(ctx, "repo", "c3", tt.prefix, tt.limit, tt.after)
+			require.NoError(t, err)
+			commits := make(map[string]string, len(got))
+			for _, entry := range got {
+				require.NotNil(t, entry.Commit, "entry %s has no commit", entry.Path)
+				commits[entry.Path] = entry.Commit.Reference
+			}
+			require.Equal(t, tt.want, commits)
+			require.Equal(t, tt.wantHasMore, hasMore)
+		})
+	}
+}
+
+func TestCatalog_NewEntryIterator(t *testing.T) {
+	gravelerData := []*graveler.ValueRecord{
+		{Key: graveler.Key("file1"), Value: catalog.MustEntryToValue(&catalog.Entry{Address: "file1", Size: 1})},
+		{Key: graveler.Key("h/file1"), Value: catalog.MustEntryToValue(&catalog.Entry{Address: "h/file1", Size: 1})},
+		{Key: graveler.Key("h/file2"), Value: catalog.MustEntryToValue(&catalog.Entry{Address: "h/file2", Size: 2})},
+		{Key: graveler.Key("h/sub/file3"), Value: catalog.MustEntryToValue(&catalog.Entry{Address: "h/sub/file3", Size: 3})},
+	}
+	tests := []struct {
+		name      string
+		prefix    string
+		delimiter string
+		want      []string
+	}{
+		{name: "all", want: []string{"file1", "h/file1", "h/file2", "h/sub/file3"}},
+		{name: "prefix", prefix: "h/", want: []string{"h/file1", "h/file2", "h/sub/file3"}},
+		{name: "prefix with delimiter", prefix: "h/", delimiter: "/", want: []string{"h/file1", "h/file2", "h/sub/"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &catalog.Catalog{
+				Store: &catalog.FakeGraveler{
+					ListIteratorFactory: catalog.NewFakeValueIteratorFactory(gravelerData),
+				},
+			}
+			it, err := c.NewEntryIterator(context.Background(), "repo", "ref", tt.prefix, tt.delimiter)
+			require.NoError(t, err)
+			defer it.Close()
+			var paths []string
+			for it.Next() {
+				paths = append(paths, it.Value().Path.String())
+			}
+			require.NoError(t, it.Err())
+			require.Equal(t, tt.want, paths)
+		})
+	}
+}
+
+func TestCatalog_PrefixesExist(t *testing.T) {
+	value := &graveler.Value{Identity: []byte("id"), Data: []byte("data")}
+	gravelerData := []*graveler.ValueRecord{
+		{Key: graveler.Key("a/file1"), Value: value},
+		{Key: graveler.Key("c/d/file2"), Value: value},
+	}
+	gravelerMock := &catalog.FakeGraveler{
+		ListIteratorFactory: catalog.NewFakeValueIteratorFactory(gravelerData),
+	}
+	c := &catalog.Catalog{
+		Store: gravelerMock,
+	}
+	ctx := context.Background()
+	got, err := c.PrefixesExist(ctx, "repo", "ref", []string{"a/", "b/", "c/d/", "c/e/", "d/", ""})
+	if err != nil {
+		t.Fatalf("PrefixesExist() error = %v", err)
+	}
+	want := map[string]bool{"a/": true, "b/": false, "c/d/": true, "c/e/": false, "d/": false, "": true}
+	if diff := deep.Equal(got, want); diff != nil {
+		t.Error("PrefixesExist() diff found", diff)
+	}
+}
+
+func TestCatalog_ListPhysicalAddresses(t *testing.T) {
+	var gravelerData []*graveler.ValueRecord
+	for _, e := range []struct{ path, address string }{
+		{path: "a", address: "mem://bucket/3"},
+		{path: "b", address: "mem://bucket/1"},
+		{path: "c", address: "mem://bucket/3"},
+		{path: "d", address: "mem://bucket/2"},
+		{path: "e", address: "mem://bucket/1"},
+		{path: "f", address: "mem://bucket/4"},
+	} {
+		gravelerData = append(gravelerData, &graveler.ValueRecord{
+			Key:   graveler.Key(e.path),
+			Value: catalog.MustEntryToValue(&catalog.Entry{Address: e.address, AddressType: catalog.AddressTypeFull}),
+		})
+	}
+	c := &catalog.Catalog{
+		Store:        &catalog.FakeGraveler{ListIteratorFactory: catalog.NewFakeValueIteratorFactory(gravelerData)},
+		BlockAdapter: testutil.NewBlockAdapterByType(t, block.BlockstoreTypeMem),
+	}
+	ctx := context.Background()
+
+	// page through with a small limit, every address should show up exactly once
+	var (
+		got   []string
+		after string
+	)
+	for {
+		page, hasMore, err := c.ListPhysicalAddresses(ctx, "repo", "ref", 2, after)
+		require.NoError(t, err)
+		got = append(got, page...)
+		if !hasMore {
+			break
+		}
+		after = page[len(page)-1]
+	}
+	require.Equal(t, []string{"mem://bucket/1", "mem://bucket/2", "mem://bucket/3", "mem://bucket/4"}, got)
+
+	page, hasMore, err := c.ListPhysicalAddresses(ctx, "repo", "ref", -1, "mem://bucket/2")
+	require.NoError(t, err)
+	require.False(t, hasMore)
+	require.Equal(t, []string{"mem://bucket/3", "mem://bucket/4"}, page)
+}
+
+func TestCatalog_EntryMatchesETag(t *testing.T) {
+	gravelerMock := &catalog.FakeGraveler{
+		KeyValue: map[string]*graveler.Value{
+			"repo/main/file": catalog.MustEntryToValue(&catalog.Entry{Address: "file", ETag: "abc123"}),
+		},
+	}
+	c := &catalog.Catalog{
+		Store: gravelerMock,
+	}
+	tests := []struct {
+		name      string
+		etag      string
+		wantMatch bool
+	}{
+		{name: "match", etag: "abc123", wantMatch: true},
+		{name: "quoted", etag: `"abc123"`, wantMatch: true},
+		{name: "changed", etag: "def456", wantMatch: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			match, err := c.EntryMatchesETag(ctx, "repo", "main", "file", tt.etag)
+			if err != nil {
+				t.Fatalf("EntryMatchesETag() error = %v", err)
+			}
+			if match != tt.wantMatch {
+				t.Errorf("EntryMatchesETag() = %t, want %t", match, tt.wantMatch)
+			}
+		})
+	}
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := c.EntryMatchesETag(context.Background(), "repo", "main", "missing", "abc123")
+		require.ErrorIs(t, err, graveler.ErrNotFound)
+	})
+}
+
+func TestCatalog_DiffCommit(t *testing.T) {
+	value := func(address string) *graveler.Value {
+		return catalog.MustEntryToValue(&catalog.Entry{Address: address})
+	}
+	// changes of the merge commit "m" compared to each of its parents
+	diffs := map[string][]graveler.Diff{
+		"p1..m": {
+			{Type: graveler.DiffTypeAdded, Key: graveler.Key("from-p2"), Value: value("data/p2")},
+		},
+		"p2..m": {
+			{Type: graveler.DiffTypeAdded, Key: graveler.Key("from-p1/a"), Value: value("data/p1a")},
+			{Type: graveler.DiffTypeChanged, Key: graveler.Key("from-p1/b"), Value: value("data/p1b")},
+		},
+	}
+	gravelerMock := &catalog.FakeGraveler{
+		CommitIteratorFactory: func() graveler.CommitIterator {
+			return gUtils.NewFakeCommitIterator([]*graveler.CommitRecord{
+				{CommitID: "m", Commit: &graveler.Commit{Parents: graveler.CommitParents{"p1", "p2"}}},
+				{CommitID: "root", Commit: &graveler.Commit{}},
+			})
+		},
+		RefsDiffIteratorFactory: func(left, right graveler.Ref) graveler.DiffIterator {
+			return gUtils.NewDiffIter(diffs[left.String()+".."+right.String()])
+		},
+	}
+	c := &catalog.Catalog{
+		Store: gravelerMock,
+	}
+	ctx := context.Background()
+	tests := []struct {
+		name         string
+		commit       string
+		parentNumber int
+		params       catalog.DiffParams
+		want         []string
+		wantHasMore  bool
+		wantErr      error
+	}{
+		{name: "default parent", commit: "m", params: catalog.DiffParams{Limit: -1}, want: []string{"from-p2"}},
+		{name: "first parent", commit: "m", parentNumber: 1, params: catalog.DiffParams{Limit: -1}, want: []string{"from-p2"}},
+		{name: "second parent", commit: "m", parentNumber: 2, params: catalog.DiffParams{Limit: -1}, want: []string{"from-p1/a", "from-p1/b"}},
+		{name: "second parent page", commit: "m", parentNumber: 2, params: catalog.DiffParams{Limit: 1}, want: []string{"from-p1/a"}, wantHasMore: true},
+		{name: "second parent delimiter", commit: "m", parentNumber: 2, params: catalog.DiffParams{Limit: -1, Delimiter: "/"}, want: []string{"from-p1/"}},
+		{name: "parent out of range", commit: "m", parentNumber: 3, wantErr: graveler.ErrParentOutOfRange},
+		{name: "negative parent", commit: "m", parentNumber: -1, wantErr: graveler.ErrInvalidValue},
+		{name: "no parents", commit: "root", wantErr: graveler.ErrParentOutOfRange},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, hasMore, err := c.DiffCommit(ctx, "repo", tt.commit, tt.parentNumber, tt.params)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			paths := make([]string, 0, len(got))
+			for _, d := range got {
+				paths = append(paths, d.Path)
+			}
+			require.Equal(t, tt.want, paths)
+			require.Equal(t, tt.wantHasMore, hasMore)
+		})
+	}
+}
+
+func TestCatalog_DiffSummary(t *testing.T) {
+	value := func(size int64) *graveler.Value {
+		return catalog.MustEntryToValue(&catalog.Entry{Address: "addr", Size: size})
+	}
+	// graveler diff semantics: a removed path carries its left value, added and changed paths their right value
+	diffs := []graveler.Diff{
+		{Type: graveler.DiffTypeAdded, Key: graveler.Key("a"), Value: value(10)},
+		{Type: graveler.DiffTypeChanged, Key: graveler.Key("b"), Value: value(20), LeftIdentity: []byte("left-b")},
+		{Type: graveler.DiffTypeRemoved, Key: graveler.Key("c"), Value: value(100), LeftIdentity: []byte("left-c")},
+		{Type: graveler.DiffTypeAdded, Key: graveler.Key("d"), Value: value(1)},
+	}
+	gravelerMock := &catalog.FakeGraveler{
+		KeyValue: map[string]*graveler.Value{
+			"repo/left/b": value(5),
+			"repo/left/c": value(100),
+		},
+		DiffIteratorFactory: func() graveler.DiffIterator {
+			return gUtils.NewDiffIter(diffs)
+		},
+	}
+	c := &catalog.Catalog{
+		Store: gravelerMock,
+	}
+	stats, err := c.DiffSummary(context.Background(), "repo", "left", "right")
+	require.NoError(t, err)
+	require.Equal(t, &catalog.DiffStats{
+		Added:        2,
+		Changed:      1,
+		Removed:      1,
+		BytesAdded:   31,
+		BytesRemoved: 105,
+	}, stats)
+}
+
+func TestCatalog_ListDeletedPaths(t *testing.T) {
+	diffs := []graveler.Diff{
+		{Type: graveler.DiffTypeRemoved, Key: graveler.Key("a")},
+		{Type: graveler.DiffTypeAdded, Key: graveler.Key("b")},
+		{Type: graveler.DiffTypeRemoved, Key: graveler.Key("c")},
+		{Type: graveler.DiffTypeChanged, Key: graveler.Key("d")},
+		{Type: graveler.DiffTypeRemoved, Key: graveler.Key("e")},
+	}
+	gravelerMock := &catalog.FakeGraveler{
+		DiffIteratorFactory: func() graveler.DiffIterator { return gUtils.NewDiffIter(diffs) },
+	}
+	c := &catalog.Catalog{
+		Store: gravelerMock,
+	}
+	tests := []struct {
+		name        string
+		limit       int
+		after       string
+		want        []string
+		wantHasMore bool
+	}{
+		{name: "all", limit: -1, want: []string{"a", "c", "e"}},
+		{name: "first", limit: 1, want: []string{"a"}, wantHasMore: true},
+		{name: "after", limit: 1, after: "a", want: []string{"c"}, wantHasMore: true},
+		{name: "last", limit: 2, after: "c", want: []string{"e"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			got, hasMore, err := c.ListDeletedPaths(ctx, "repo", "from", "to", tt.limit, tt.after)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+			require.Equal(t, tt.wantHasMore, hasMore)
+		})
+	}
+}
+
+func TestCatalog_MergeConflicts(t *testing.T) {
+	diffs := []graveler.Diff{
+		{Type: graveler.DiffTypeConflict, Key: graveler.Key("a")},
+		{Type: graveler.DiffTypeAdded, Key: graveler.Key("b")},
+		{Type: graveler.DiffTypeConflict, Key: graveler.Key("c")},
+		{Type: graveler.DiffTypeChanged, Key: graveler.Key("d")},
+	}
+	gravelerMock := &catalog.FakeGraveler{
+		DiffIteratorFactory: func() graveler.DiffIterator { return gUtils.NewDiffIter(diffs) },
+	}
+	c := &catalog.Catalog{
+		Store: gravelerMock,
+	}
+	tests := []struct {
+		name        string
+		limit       int
+		after       string
+		want        []string
+		wantHasMore bool
+	}{
+		{name: "all", limit: -1, want: []string{"a", "c"}},
+		{name: "first", limit: 1, want: []string{"a"}, wantHasMore: true},
+		{name: "after", limit: 1, after: "a", want: []string{"c"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, hasMore, err := c.MergeConflicts(context.Background(), "repo", "dest", "source", tt.limit, tt.after)
+			require.NoError(t, err)
+			paths := make([]string, 0, len(got))
+			for _, d := range got {
+				require.Equal(t, catalog.DifferenceTypeConflict, d.Type)
+				paths = append(paths, d.Path)
+			}
+			require.Equal(t, tt.want, paths)
+			require.Equal(t, tt.wantHasMore, hasMore)
+		})
+	}
+}
+
+func TestCatalog_MergePreview(t *testing.T) {
+	value := func(address string) *graveler.Value {
+		return catalog.MustEntryToValue(&catalog.Entry{Address: address})
+	}
+	diffs := []graveler.Diff{
+		{Type: graveler.DiffTypeAdded, Key: graveler.Key("a"), Value: value("data/a")},
+		{Type: graveler.DiffTypeChanged, Key: graveler.Key("b/1"), Value: value("data/b1")},
+		{Type: graveler.DiffTypeRemoved, Key: graveler.Key("b/2"), Value: value("data/b2")},
+		{Type: graveler.DiffTypeAdded, Key: graveler.Key("c"), Value: value("data/c")},
+	}
+	var uncommitted []graveler.Diff
+	gravelerMock := &catalog.FakeGraveler{
+		DiffIteratorFactory:            func() graveler.DiffIterator { return gUtils.NewDiffIter(diffs) },
+		UncommittedDiffIteratorFactory: func() graveler.DiffIterator { return gUtils.NewDiffIter(uncommitted) },
+	}
+	c := &catalog.Catalog{
+		Store: gravelerMock,
+	}
+	ctx := context.Background()
+	tests := []struct {
+		name        string
+		params      catalog.DiffParams
+		want        []string
+		wantTypes   []catalog.DifferenceType
+		wantHasMore bool
+	}{
+		{
+			name:      "all",
+			params:    catalog.DiffParams{Limit: -1},
+			want:      []string{"a", "b/1", "b/2", "c"},
+			wantTypes: []catalog.DifferenceType{catalog.DifferenceTypeAdded, catalog.DifferenceTypeChanged, catalog.DifferenceTypeRemoved, catalog.DifferenceTypeAdded},
+		},
+		{
+			name:        "page",
+			params:      catalog.DiffParams{Limit: 2, After: "a"},
+			want:        []string{"b/1", "b/2"},
+			wantTypes:   []catalog.DifferenceType{catalog.DifferenceTypeChanged, catalog.DifferenceTypeRemoved},
+			wantHasMore: true,
+		},
+		{
+			name:      "delimiter",
+			params:    catalog.DiffParams{Limit: -1, Delimiter: "/"},
+			want:      []string{"a", "b/", "c"},
+			wantTypes: []catalog.DifferenceType{catalog.DifferenceTypeAdded, catalog.DifferenceTypePrefixChanged, catalog.DifferenceTypeAdded},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, hasMore, err := c.MergePreview(ctx, "repo", "main", "feature", tt.params)
+			require.NoError(t, err)
+			paths := make([]string, 0, len(got))
+			types := make([]catalog.DifferenceType, 0, len(got))
+			for _, d := range got {
+				paths = append(paths, d.Path)
+				types = append(types, d.Type)
+			}
+			require.Equal(t, tt.want, paths)
+			require.Equal(t, tt.wantTypes, types)
+			require.Equal(t, tt.wantHasMore, hasMore)
+		})
+	}
+
+	t.Run("dirty destination", func(t *testing.T) {
+		uncommitted = []graveler.Diff{{Type: graveler.DiffTypeAdded, Key: graveler.Key("d"), Value: value("data/d")}}
+		_, _, err := c.MergePreview(ctx, "repo", "main", "feature", catalog.DiffParams{Limit: -1})
+		require.ErrorIs(t, err, graveler.ErrDirtyBranch)
+	})
+}
+
+func TestCatalog_ApplyCommitPatch(t *testing.T) {
+	gravelerMock := &catalog.FakeGraveler{
+		KeyValue: map[string]*graveler.Value{},
+	}
+	c := &catalog.Catalog{
+		Store: gravelerMock,
+	}
+	ctx := context.Background()
+
+	patch := `{"path":"a","type":"added","entry":{"Path":"a","PhysicalAddress":"s3://bucket/a","AddressType":2,"Size":3}}
+{"path":"b","type":"removed"}
+`
+	require.NoError(t, c.ApplyCommitPatch(ctx, "repo", "main", strings.NewReader(patch)))
+	ent, err := catalog.ValueToEntry(gravelerMock.KeyValue["repo/main/a"])
+	require.NoError(t, err)
+	require.Equal(t, "s3://bucket/a", ent.Address)
+	require.Equal(t, catalog.Entry_FULL, ent.AddressType)
+	require.Equal(t, int64(3), ent.Size)
+
+	t.Run("invalid type", func(t *testing.T) {
+		err := c.ApplyCommitPatch(ctx, "repo", "main", strings.NewReader(`{"path":"c","type":"moved"}`))
+		require.ErrorIs(t, err, catalog.ErrUnknownDiffType)
+		require.NotContains(t, gravelerMock.KeyValue, "repo/main/c")
+	})
+
+	t.Run("missing entry", func(t *testing.T) {
+		err := c.ApplyCommitPatch(ctx, "repo", "main", strings.NewReader(`{"path":"c","type":"added"}`))
+		require.ErrorIs(t, err, graveler.ErrInvalidValue)
+	})
+}
+
+func TestCatalog_GetEntryAtCommit(t *testing.T) {
+	const commitID = "c2b4a2b17d6e5b5e8f4dd1c6e30e2d6e1b8cfb1c2f0e0d8b5a6a1c9b3e4f5a6b"
+	gravelerMock := &catalog.FakeGraveler{
+		KeyValue: map[string]*graveler.Value{
+			"repo/" + commitID + "/a": catalog.MustEntryToValue(&catalog.Entry{Address: "data/a", Size: 1}),
+			"repo/main/a":             catalog.MustEntryToValue(&catalog.Entry{Address: "data/a2", Size: 2}),
+		},
+	}
+	c := &catalog.Catalog{
+		Store: gravelerMock,
+	}
+	ctx := context.Background()
+
+	ent, err := c.GetEntryAtCommit(ctx, "repo", commitID, "a")
+	require.NoError(t, err)
+	require.Equal(t, "data/a", ent.PhysicalAddress)
+
+	t.Run("branch ref", func(t *testing.T) {
+		_, err := c.GetEntryAtCommit(ctx, "repo", "main", "a")
+		require.ErrorIs(t, err, graveler.ErrInvalidCommitID)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := c.GetEntryAtCommit(ctx, "repo", commitID, "b")
+		require.ErrorIs(t, err, graveler.ErrNotFound)
+	})
+}
+
+func TestCatalog_GetEntryOrPrefix(t *testing.T) {
+	gravelerData := []*graveler.ValueRecord{
+		{Key: graveler.Key("data/file1"), Value: catalog.MustEntryToValue(&catalog.Entry{Address: "file1", Size: 1})},
+		{Key: graveler.Key("file2"), Value: catalog.MustEntryToValue(&catalog.Entry{Address: "file2", Size: 2})},
+	}
+	keyValue := make(map[string]*graveler.Value)
+	for _, r := range gravelerData {
+		keyValue["repo/ref/"+r.Key.String()] = r.Value
+	}
+	c := &catalog.Catalog{
+		Store: &catalog.FakeGraveler{
+			KeyValue:            keyValue,
+			ListIteratorFactory: catalog.NewFakeValueIteratorFactory(gravelerData),
+		},
+	}
+	ctx := context.Background()
+
+	t.Run("object", func(t *testing.T) {
+		entry, err := c.GetEntryOrPrefix(ctx, "repo", "ref", "file2", "/")
+		require.NoError(t, err)
+		require.False(t, entry.CommonLevel)
+		require.Equal(t, "file2", entry.Path)
+		require.Equal(t, "file2", entry.PhysicalAddress)
+	})
+
+	t.Run("prefix", func(t *testing.T) {
+		entry, err := c.GetEntryOrPrefix(ctx, "repo", "ref", "data", "/")
+		require.NoError(t, err)
+		require.True(t, entry.CommonLevel)
+		require.Equal(t, "data/", entry.Path)
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		_, err := c.GetEntryOrPrefix(ctx, "repo", "ref", "dat", "/")
+		require.ErrorIs(t, err, graveler.ErrNotFound)
+	})
+}
+
+func TestCatalog_GetEntries(t *testing.T) {
+	gravelerMock := &catalog.FakeGraveler{
+		KeyValue: map[string]*graveler.Value{
+			"repo/main/a": catalog.MustEntryToValue(&catalog.Entry{Address: "data/a", Size: 1}),
+			"repo/main/b": catalog.MustEntryToValue(&catalog.Entry{Address: "data/b", Size: 2}),
+		},
+	}
+	c := &catalog.Catalog{
+		Store: gravelerMock,
+	}
+	ctx := context.Background()
+
+	entries, err := c.GetEntries(ctx, "repo", "main", []string{"a", "b", "missing", "a"}, catalog.GetEntryParams{})
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	require.Equal(t, "data/a", entries["a"].PhysicalAddress)
+	require.Equal(t, int64(2), entries["b"].Size)
+	require.NotContains(t, entries, "missing")
+
+	t.Run("too many paths", func(t *testing.T) {
+		_, err := c.GetEntries(ctx, "repo", "main", make([]string, catalog.GetEntriesMaxSize+1), catalog.GetEntryParams{})
+		require.ErrorIs(t, err, graveler.ErrInvalidValue)
+	})
+}
+
+func TestCatalog_CreateEntries(t *testing.T) {
+	gravelerMock := &catalog.FakeGraveler{
+		KeyValue: map[string]*graveler.Value{},
+	}
+	c := &catalog.Catalog{
+		Store: gravelerMock,
+	}
+	ctx := context.Background()
+
+	entries := []catalog.DBEntry{
+		{Path: "a", PhysicalAddress: "data/a", Size: 1},
+		{Path: "b", PhysicalAddress: "data/b", Size: 2},
+	}
+	require.NoError(t, c.CreateEntries(ctx, "repo", "main", entries))
+	for _, entry := range entries {
+		ent, err := catalog.ValueToEntry(gravelerMock.KeyValue["repo/main/"+entry.Path])
+		require.NoError(t, err)
+		require.Equal(t, entry.PhysicalAddress, ent.Address)
+		require.Equal(t, entry.Size, ent.Size)
+	}
+
+	t.Run("invalid path", func(t *testing.T) {
+		err := c.CreateEntries(ctx, "repo", "main", []catalog.DBEntry{{Path: "c", PhysicalAddress: "data/c"}, {Path: ""}})
+		require.Error(t, err)
+		require.NotContains(t, gravelerMock.KeyValue, "repo/main/c")
+	})
+}
+
+func TestCatalog_DeleteEntriesReportMissing(t *testing.T) {
+	gravelerMock := &catalog.FakeGraveler{
+		KeyValue: map[string]*graveler.Value{
+			"repo/main/a": catalog.MustEntryToValue(&catalog.Entry{Address: "data/a"}),
+			"repo/main/c": catalog.MustEntryToValue(&catalog.Entry{Address: "data/c"}),
+		},
+	}
+	c := &catalog.Catalog{
+		Store: gravelerMock,
+	}
+	ctx := context.Background()
+
+	missing, err := c.DeleteEntriesReportMissing(ctx, "repo", "main", []string{"a", "b", "c", "d"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"b", "d"}, missing)
+	require.Empty(t, gravelerMock.KeyValue)
+
+	t.Run("nothing missing", func(t *testing.T) {
+		gravelerMock.KeyValue["repo/main/e"] = catalog.MustEntryToValue(&catalog.Entry{Address: "data/e"})
+		missing, err := c.DeleteEntriesReportMissing(ctx, "repo", "main", []string{"e"})
+		require.NoError(t, err)
+		require.Empty(t, missing)
+	})
+
+	t.Run("invalid path", func(t *testing.T) {
+		_, err := c.DeleteEntriesReportMissing(ctx, "repo", "main", []string{"f", strings.Repeat("x", catalog.MaxPathLength+1)})
+		require.ErrorIs(t, err, graveler.ErrInvalidValue)
+	})
+
+	t.Run("maintenance", func(t *testing.T) {
+		c := &catalog.Catalog{
+			Store: &catalog.FakeGraveler{MaintenanceReason: "migration"},
+		}
+		_, err := c.DeleteEntriesReportMissing(ctx, "repo", "main", []string{"missing"})
+		require.ErrorIs(t, err, graveler.ErrRepositoryInMaintenance)
+	})
+}
+
+func TestCatalog_ShallowCopyEntry(t *testing.T) {
+	srcValue := catalog.MustEntryToValue(&catalog.Entry{
+		Address:      "data/abc",
+		AddressType:  catalog.Entry_RELATIVE,
+		Size:         5,
+		ETag:         "etag1",
+		LastModified: timestamppb.New(time.Unix(1, 0)),
+	})
+	gravelerMock := &catalog.FakeGraveler{
+		KeyValue: map[string]*graveler.Value{
+			"repo/main/src": srcValue,
+		},
+	}
+	c := &catalog.Catalog{
+		Store: gravelerMock,
+	}
+	ctx := context.Background()
+
+	dst, err := c.ShallowCopyEntry(ctx, "repo", "main", "src", "dst")
+	require.NoError(t, err)
+	require.Equal(t, "dst", dst.Path)
+	require.Equal(t, "data/abc", dst.PhysicalAddress)
+	ent, err := catalog.ValueToEntry(gravelerMock.KeyValue["repo/main/dst"])
+	require.NoError(t, err)
+	require.Equal(t, "data/abc", ent.Address)
+	require.Equal(t, int64(5), ent.Size)
+	_, err = c.GetEntry(ctx, "repo", "main", "src", catalog.GetEntryParams{})
+	require.ErrorIs(t, err, graveler.ErrNotFound)
+	require.Equal(t, "etag1", ent.ETag)
+
+	t.Run("missing source", func(t *testing.T) {
+		_, err := c.ShallowCopyEntry(ctx, "repo", "main", "missing", "dst2")
+		require.ErrorIs(t, err, graveler.ErrNotFound)
+		require.NotContains(t, gravelerMock.KeyValue, "repo/main/dst2")
+	})
+}
+
+func TestCatalog_MoveEntry(t *testing.T) {
+	srcValue := catalog.MustEntryToValue(&catalog.Entry{
+		Address:      "data/abc",
+		AddressType:  catalog.Entry_RELATIVE,
+		Size:         5,
+		LastModified: timestamppb.New(time.Unix(1, 0)),
+	})
+	gravelerMock := &catalog.FakeGraveler{
+		KeyValue: map[string]*graveler.Value{
+			"repo/main/src": srcValue,
+			"repo/main/dst": catalog.MustEntryToValue(&catalog.Entry{Address: "data/old", Size: 1}),
+		},
+	}
+	c := &catalog.Catalog{
+		Store: gravelerMock,
+	}
+	ctx := context.Background()
+
+	dst, err := c.MoveEntry(ctx, "repo", "main", "src", "dst")
+	require.NoError(t, err)
+	require.Equal(t, "dst", dst.Path)
+	ent, err := catalog.ValueToEntry(gravelerMock.KeyValue["repo/main/dst"])
+	require.NoError(t, err)
+	require.Equal(t, "data/abc", ent.Address)
+	require.Equal(t, int64(5), ent.Size)
+
+	t.Run("missing source", func(t *testing.T) {
+		_, err := c.MoveEntry(ctx, "repo", "main", "missing", "dst2")
+		require.ErrorIs(t, err, graveler.ErrNotFound)
+		require.NotContains(t, gravelerMock.KeyValue, "repo/main/dst2")
+	})
+
+	t.Run("same path", func(t *testing.T) {
+		_, err := c.MoveEntry(ctx, "repo", "main", "src", "src")
+		require.ErrorIs(t, err, graveler.ErrInvalidValue)
+	})
+
+	t.Run("restore destination on failed delete", func(t *testing.T) {
+		errDelete := errors.New("delete failed")
+		oldDstValue := catalog.MustEntryToValue(&catalog.Entry{Address: "data/old", Size: 1})
+		failingMock := &catalog.FakeGraveler{
+			KeyValue: map[string]*graveler.Value{
+				"repo/main/src": srcValue,
+				"repo/main/dst": oldDstValue,
+			},
+			DeleteErr: errDelete,
+		}
+		failing := &catalog.Catalog{
+			Store: failingMock,
+		}
+		_, err := failing.MoveEntry(ctx, "repo", "main", "src", "dst")
+		require.ErrorIs(t, err, errDelete)
+		require.Equal(t, map[string]*graveler.Value{
+			"repo/main/src": srcValue,
+			"repo/main/dst": oldDstValue,
+		}, failingMock.KeyValue)
+	})
+}
+
+func TestCatalog_ApplyMergeOperations(t *testing.T) {
+	value := func(address string) *graveler.Value {
+		return catalog.MustEntryToValue(&catalog.Entry{Address: address})
+	}
+	diff := func(path string, typ catalog.DifferenceType) catalog.Difference {
+		return catalog.Difference{DBEntry: catalog.DBEntry{Path: path}, Type: typ}
+	}
+	addressDiff := func(path, address string, typ catalog.DifferenceType) catalog.Difference {
+		return catalog.Difference{DBEntry: catalog.DBEntry{Path: path, PhysicalAddress: address}, Type: typ}
+	}
+	newGraveler := func() *catalog.FakeGraveler {
+		return &catalog.FakeGraveler{
+			KeyValue: map[string]*graveler.Value{
+				"repo/source/a": value("data/a"),
+				"repo/source/b": value("data/b2"),
+				"repo/main/b":   value("data/b1"),
+				"repo/main/c":   value("data/c"),
+			},
+			CommitIteratorFactory: func() graveler.CommitIterator {
+				return gUtils.NewFakeCommitIterator([]*graveler.CommitRecord{
+					{CommitID: "applied", Commit: &graveler.Commit{Committer: "committer"}},
+				})
+			},
+			CommitKeysID: "applied",
+		}
+	}
+	ctx := context.Background()
+
+	t.Run("apply", func(t *testing.T) {
+		gravelerMock := newGraveler()
+		c := &catalog.Catalog{Store: gravelerMock}
+		ops := catalog.Differences{
+			diff("a", catalog.DifferenceTypeAdded),
+			diff("b", catalog.DifferenceTypeChanged),
+			diff("c", catalog.DifferenceTypeRemoved),
+		}
+		commitLog, err := c.ApplyMergeOperations(ctx, "repo", "main", ops, "source", "committer")
+		require.NoError(t, err)
+		require.Equal(t, "applied", commitLog.Reference)
+		require.Equal(t, []graveler.Key{graveler.Key("a"), graveler.Key("b"), graveler.Key("c")}, gravelerMock.CommittedKeys)
+		require.Equal(t, map[string]*graveler.Value{
+			"repo/source/a": value("data/a"),
+			"repo/source/b": value("data/b2"),
+			"repo/main/a":   value("data/a"),
+			"repo/main/b":   value("data/b2"),
+		}, gravelerMock.KeyValue)
+	})
+
+	staleTests := []struct {
+		name string
+		ops  catalog.Differences
+	}{
+		{name: "added missing from source", ops: catalog.Differences{diff("a", catalog.DifferenceTypeAdded), diff("d", catalog.DifferenceTypeAdded)}},
+		{name: "changed missing from source", ops: catalog.Differences{diff("a", catalog.DifferenceTypeAdded), diff("c", catalog.DifferenceTypeChanged)}},
+		{name: "removed exists on source", ops: catalog.Differences{diff("c", catalog.DifferenceTypeRemoved), diff("b", catalog.DifferenceTypeRemoved)}},
+		{name: "added exists on target", ops: catalog.Differences{diff("a", catalog.DifferenceTypeAdded), diff("b", catalog.DifferenceTypeAdded)}},
+		{name: "changed missing from target", ops: catalog.Differences{diff("b", catalog.DifferenceTypeChanged), diff("a", catalog.DifferenceTypeChanged)}},
+		{name: "source address changed", ops: catalog.Differences{diff("b", catalog.DifferenceTypeChanged), addressDiff("a", "data/old", catalog.DifferenceTypeAdded)}},
+		{name: "target address changed", ops: catalog.Differences{diff("a", catalog.DifferenceTypeAdded), addressDiff("c", "data/other", catalog.DifferenceTypeRemoved)}},
+	}
+	for _, tt := range staleTests {
+		t.Run(tt.name, func(t *testing.T) {
+			gravelerMock := newGraveler()
+			c := &catalog.Catalog{Store: gravelerMock}
+			_, err := c.ApplyMergeOperations(ctx, "repo", "main", tt.ops, "source", "committer")
+			require.ErrorIs(t, err, catalog.ErrStaleOperation)
+			// nothing is written before all operations are validated
+			require.Equal(t, newGraveler().KeyValue, gravelerMock.KeyValue)
+			require.Nil(t, gravelerMock.CommittedKeys)
+		})
+	}
+
+	t.Run("removed missing from target", func(t *testing.T) {
+		gravelerMock := newGraveler()
+		c := &catalog.Catalog{Store: gravelerMock}
+		ops := catalog.Differences{
+			addressDiff("a", "data/a", catalog.DifferenceTypeAdded),
+			diff("d", catalog.DifferenceTypeRemoved),
+			addressDiff("c", "data/c", catalog.DifferenceTypeRemoved),
+		}
+		_, err := c.ApplyMergeOperations(ctx, "repo", "main", ops, "source", "committer")
+		require.NoError(t, err)
+		require.Equal(t, []graveler.Key{graveler.Key("a"), graveler.Key("c")}, gravelerMock.CommittedKeys)
+	})
+
+	t.Run("reset on failed staging", func(t *testing.T) {
+		errDelete := errors.New("delete failed")
+		gravelerMock := newGraveler()
+		gravelerMock.DeleteErr = errDelete
+		c := &catalog.Catalog{Store: gravelerMock}
+		ops := catalog.Differences{
+			diff("a", catalog.DifferenceTypeAdded),
+			diff("b", catalog.DifferenceTypeChanged),
+			diff("c", catalog.DifferenceTypeRemoved),
+		}
+		_, err := c.ApplyMergeOperations(ctx, "repo", "main", ops, "source", "committer")
+		require.ErrorIs(t, err, errDelete)
+		require.Equal(t, []graveler.Key{graveler.Key("a"), graveler.Key("b")}, gravelerMock.ResetKeys)
+		require.Nil(t, gravelerMock.CommittedKeys)
+	})
+
+	t.Run("reset on failed commit", func(t *testing.T) {
+		errCommit := errors.New("commit failed")
+		gravelerMock := newGraveler()
+		gravelerMock.CommitKeysErr = errCommit
+		c := &catalog.Catalog{Store: gravelerMock}
+		ops := catalog.Differences{
+			diff("a", catalog.DifferenceTypeAdded),
+			diff("b", catalog.DifferenceTypeChanged),
+			diff("c", catalog.DifferenceTypeRemoved),
+		}
+		_, err := c.ApplyMergeOperations(ctx, "repo", "main", ops, "source", "committer")
+		require.ErrorIs(t, err, errCommit)
+		require.Equal(t, []graveler.Key{graveler.Key("a"), graveler.Key("b"), graveler.Key("c")}, gravelerMock.ResetKeys)
+	})
+}
+
+func TestCatalog_PhysicalAddressExists(t *testing.T) {
+	ctx := context.Background()
+	blockAdapter := testutil.NewBlockAdapterByType(t, block.BlockstoreTypeMem)
+	err := blockAdapter.Put(ctx, block.ObjectPointer{
+		Identifier:     "mem://bucket/data/exists",
+		IdentifierType: block.IdentifierTypeFull,
+	}, 4, strings.NewReader("data"), block.PutOpts{})
+	require.NoError(t, err)
+	c := &catalog.Catalog{
+		Store:        &catalog.FakeGraveler{},
+		BlockAdapter: blockAdapter,
+	}
+
+	exists, err := c.PhysicalAddressExists(ctx, "repo", "mem://bucket/data/exists", catalog.AddressTypeFull)
+	require.NoError(t, err)
+	require.True(t, exists)
+
+	exists, err = c.PhysicalAddressExists(ctx, "repo", "mem://bucket/data/missing", catalog.AddressTypeFull)
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	_, err = c.PhysicalAddressExists(ctx, "repo", "", catalog.AddressTypeFull)
+	require.ErrorIs(t, err, validator.ErrRequiredValue)
+}
+
+func TestCatalog_VerifyEntry(t *testing.T) {
+	ctx := context.Background()
+	blockAdapter := testutil.NewBlockAdapterByType(t, block.BlockstoreTypeMem)
+	checksum := func(data string) string {
+		sum := md5.Sum([]byte(data)) //nolint:gosec
+		return hex.EncodeToString(sum[:])
+	}
+	objects := map[string]string{"hello": "hello", "corrupted": "hellx", "longer": "hello world"}
+	for name, data := range objects {
+		err := blockAdapter.Put(ctx, block.ObjectPointer{
+			StorageNamespace: "mem://bucket",
+			Identifier:       "mem://bucket/" + name,
+			IdentifierType:   block.IdentifierTypeFull,
+		}, int64(len(data)), strings.NewReader(data), block.PutOpts{})
+		require.NoError(t, err)
+	}
+	value := func(name string, size int64, eTag string) *graveler.Value {
+		return catalog.MustEntryToValue(&catalog.Entry{
+			Address:     "mem://bucket/" + name,
+			AddressType: catalog.Entry_FULL,
+			Size:        size,
+			ETag:        eTag,
+		})
+	}
+	c := &catalog.Catalog{
+		Store: &catalog.FakeGraveler{
+			KeyValue: map[string]*graveler.Value{
+				"repo/main/match":     value("hello", 5, checksum("hello")),
+				"repo/main/corrupted": value("corrupted", 5, checksum("hello")),
+				"repo/main/longer":    value("longer", 5, checksum("hello")),
+				"repo/main/multipart": value("hello", 5, checksum("hello")+"-2"),
+				"repo/main/large":     value("hello", catalog.VerifyEntryMaxSize+1, checksum("hello")),
+			},
+		},
+		BlockAdapter: blockAdapter,
+	}
+	tests := []struct {
+		name    string
+		path    string
+		want    bool
+		wantErr error
+	}{
+		{name: "match", path: "match", want: true},
+		{name: "mismatch", path: "corrupted", want: false},
+		{name: "object longer than entry", path: "longer", want: false},
+		{name: "multipart checksum", path: "multipart", wantErr: catalog.ErrChecksumNotVerifiable},
+		{name: "too large", path: "large", wantErr: catalog.ErrChecksumNotVerifiable},
+		{name: "missing", path: "missing", wantErr: graveler.ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := c.VerifyEntry(ctx, "repo", "main", tt.path)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("canceled", func(t *testing.T) {
+		canceledCtx, cancel := context.WithCancel(ctx)
+		cancel()
+		_, err := c.VerifyEntry(canceledCtx, "repo", "main", "match")
+		require.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestCatalog_ScanEntriesParallel(t *testing.T) {
+	var gravelerData []*graveler.ValueRecord
+	for _, key := range []string{"a/1", "a/2", "b/1", "c", "d/1"} {
+		gravelerData = append(gravelerData, &graveler.ValueRecord{
+			Key:   graveler.Key(key),
+			Value: catalog.MustEntryToValue(&catalog.Entry{Address: key}),
+		})
+	}
+	gravelerMock := &catalog.FakeGraveler{
+		ListIteratorFactory: catalog.NewFakeValueIteratorFactory(gravelerData),
+	}
+	c := &catalog.Catalog{
+		Store: gravelerMock,
+	}
+	tests := []struct {
+		name   string
+		prefix string
+		shards int
+		want   [][]string
+	}{
+		{name: "single", prefix: "", shards: 1, want: [][]string{{"a/1", "a/2", "b/1", "c", "d/1"}}},
+		{name: "two", prefix: "", shards: 2, want: [][]string{{"a/1", "a/2", "b/1"}, {"c", "d/1"}}},
+		{name: "more shards than children", prefix: "a/", shards: 4, want: [][]string{{"a/1"}, {"a/2"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := c.ScanEntriesParallel(context.Background(), "repo", "ref", tt.prefix, tt.shards)
+			require.NoError(t, err)
+			got := make([][]string, 0, len(results))
+			for _, ch := range results {
+				paths := make([]string, 0)
+				for result := range ch {
+					require.NoError(t, result.Err)
+					paths = append(paths, result.Entry.Path)
+				}
+				got = append(got, paths)
+			}
+			require.Equal(t, tt.want, got)
+		})
+	}
+
+	_, err := c.ScanEntriesParallel(context.Background(), "repo", "ref", "", 0)
+	require.ErrorIs(t, err, graveler.ErrInvalidValue)
+}
+
+func TestCatalog_TreeShapeStats(t *testing.T) {
+	value := &graveler.Value{Identity: []byte("id"), Data: []byte("data")}
+	var gravelerData []*graveler.ValueRecord
+	for _, key := range []string{"a/1", "a/2", "a/3", "a/4", "b/c/1", "b/c/2", "d"} {
+		gravelerData = append(gravelerData, &graveler.ValueRecord{Key: graveler.Key(key), Value: value})
+	}
+	gravelerMock := &catalog.FakeGraveler{
+		ListIteratorFactory: catalog.NewFakeValueIteratorFactory(gravelerData),
+	}
+	c := &catalog.Catalog{
+		Store: gravelerMock,
+	}
+	tests := []struct {
+		name   string
+		prefix string
+		want   *catalog.TreeShape
+	}{
+		{name: "root", prefix: "", want: &catalog.TreeShape{EntryCount: 7, MaxDepth: 3, MaxFanOut: 4, MaxFanOutPrefix: "a/"}},
+		{name: "directory", prefix: "b/", want: &catalog.TreeShape{EntryCount: 2, MaxDepth: 2, MaxFanOut: 2, MaxFanOutPrefix: "b/c/"}},
+		{name: "empty", prefix: "e/", want: &catalog.TreeShape{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := c.TreeShapeStats(context.Background(), "repo", "ref", tt.prefix)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCatalog_GetRepositoryStats(t *testing.T) {
+	gravelerData := []*graveler.ValueRecord{
+		{Key: graveler.Key("a"), Value: catalog.MustEntryToValue(&catalog.Entry{Address: "data/a", Size: 3})},
+		{Key: graveler.Key("b/c"), Value: catalog.MustEntryToValue(&catalog.Entry{Address: "data/c", Size: 5})},
+	}
+	newGraveler := func(defaultBranch graveler.BranchID, branches ...*graveler.BranchRecord) *catalog.FakeGraveler {
+		return &catalog.FakeGraveler{
+			ListIteratorFactory:   catalog.NewFakeValueIteratorFactory(gravelerData),
+			BranchIteratorFactory: gUtils.NewFakeBranchIteratorFactory(branches),
+			CommitIteratorFactory: func() graveler.CommitIterator {
+				// c1 merges c0 and side into the default branch
+				return gUtils.NewFakeCommitIterator([]*graveler.CommitRecord{
+					{CommitID: "c1", Commit: &graveler.Commit{Parents: graveler.CommitParents{"c0", "side"}}},
+					{CommitID: "side", Commit: &graveler.Commit{Parents: graveler.CommitParents{"c0"}}},
+					{CommitID: "c0", Commit: &graveler.Commit{}},
+				})
+			},
+			DefaultBranchID: defaultBranch,
+		}
+	}
+	ctx := context.Background()
+
+	t.Run("committed", func(t *testing.T) {
+		c := &catalog.Catalog{
+			Store: newGraveler("main",
+				&graveler.BranchRecord{BranchID: "feature", Branch: &graveler.Branch{CommitID: "c2"}},
+				&graveler.BranchRecord{BranchID: "main", Branch: &graveler.Branch{CommitID: "c1"}},
+			),
+		}
+		stats, err := c.GetRepositoryStats(ctx, "repo")
+		require.NoError(t, err)
+		require.Equal(t, &catalog.RepositoryStats{Branches: 2, CommitID: "c1", Commits: 3, Objects: 2, Bytes: 8}, stats)
+	})
+
+	t.Run("bare", func(t *testing.T) {
+		c := &catalog.Catalog{
+			Store: newGraveler("main", &graveler.BranchRecord{BranchID: "main", Branch: &graveler.Branch{}}),
+		}
+		stats, err := c.GetRepositoryStats(ctx, "repo")
+		require.NoError(t, err)
+		require.Equal(t, &catalog.RepositoryStats{Branches: 1}, stats)
+	})
+
+	t.Run("missing default branch", func(t *testing.T) {
+		c := &catalog.Catalog{
+			Store: newGraveler("main", &graveler.BranchRecord{BranchID: "feature", Branch: &graveler.Branch{CommitID: "c2"}}),
+		}
+		_, err := c.GetRepositoryStats(ctx, "repo")
+		require.ErrorIs(t, err, graveler.ErrNotFound)
+	})
+}
+
+func TestCatalog_CountUncommittedChanges(t *testing.T) {
+	gravelerMock := &catalog.FakeGraveler{
+		BranchIteratorFactory: gUtils.NewFakeBranchIteratorFactory([]*graveler.BranchRecord{
+			{BranchID: "branch1", Branch: &graveler.Branch{CommitID: "c1"}},
+			{BranchID: "branch2", Branch: &graveler.Branch{CommitID: "c1"}},
+		}),
+		DiffIteratorFactory: func() graveler.DiffIterator {
+			return gUtils.NewDiffIter([]graveler.Diff{
+				{Type: graveler.DiffTypeAdded, Key: graveler.Key("a")},
+				{Type: graveler.DiffTypeRemoved, Key: graveler.Key("b")},
+			})
+		},
+	}
+	c := &catalog.Catalog{
+		Store: gravelerMock,
+	}
+	counts, err := c.CountUncommittedChanges(context.Background(), "repo")
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{"branch1": 2, "branch2": 2}, counts)
+}
+
+func TestCatalog_HasUncommittedChanges(t *testing.T) {
+	ctx := context.Background()
+	t.Run("dirty", func(t *testing.T) {
+		c := &catalog.Catalog{
+			Store: &catalog.FakeGraveler{
+				DiffIteratorFactory: func() graveler.DiffIterator {
+					return gUtils.NewDiffIter([]graveler.Diff{{Type: graveler.DiffTypeAdded, Key: graveler.Key("a")}})
+				},
+			},
+		}
+		dirty, err := c.HasUncommittedChanges(ctx, "repo", "main")
+		require.NoError(t, err)
+		require.True(t, dirty)
+	})
+
+	t.Run("clean", func(t *testing.T) {
+		c := &catalog.Catalog{
+			Store: &catalog.FakeGraveler{
+				DiffIteratorFactory: func() graveler.DiffIterator {
+					return gUtils.NewDiffIter(nil)
+				},
+			},
+		}
+		dirty, err := c.HasUncommittedChanges(ctx, "repo", "main")
+		require.NoError(t, err)
+		require.False(t, dirty)
+	})
+}
+
+func TestCatalog_PreviewCommit(t *testing.T) {
+	c := &catalog.Catalog{
+		Store: &catalog.FakeGraveler{
+			BranchIteratorFactory: gUtils.NewFakeBranchIteratorFactory([]*graveler.BranchRecord{
+				{BranchID: "main", Branch: &graveler.Branch{CommitID: "c1"}},
+			}),
+			DiffIteratorFactory: func() graveler.DiffIterator {
+				return gUtils.NewDiffIter([]graveler.Diff{
+					{Type: graveler.DiffTypeAdded, Key: graveler.Key("added"), Value: catalog.MustEntryToValue(&catalog.Entry{Address: "new", Size: 3})},
+					{Type: graveler.DiffTypeRemoved, Key: graveler.Key("removed")},
+				})
+			},
+		},
+	}
+	preview, err := c.PreviewCommit(context.Background(), "repo", "main")
+	require.NoError(t, err)
+	require.Equal(t, "c1", preview.BaseCommitID)
+	require.False(t, preview.HasMore)
+	require.Len(t, preview.Changes, 2)
+	require.Equal(t, "added", preview.Changes[0].Path)
+	require.Equal(t, catalog.DifferenceTypeAdded, preview.Changes[0].Type)
+	require.Equal(t, "removed", preview.Changes[1].Path)
+	require.Equal(t, catalog.DifferenceTypeRemoved, preview.Changes[1].Type)
+}
+
+func TestCatalog_DiffUncommittedDetailed(t *testing.T) {
+	gravelerMock := &catalog.FakeGraveler{
+		KeyValue: map[string]*graveler.Value{
+			"repo/c1/changed": catalog.MustEntryToValue(&catalog.Entry{Address: "old", Size: 1}),
+			"repo/c1/removed": catalog.MustEntryToValue(&catalog.Entry{Address: "gone", Size: 2}),
+		},
+		BranchIteratorFactory: gUtils.NewFakeBranchIteratorFactory([]*graveler.BranchRecord{
+			{BranchID: "main", Branch: &graveler.Branch{CommitID: "c1"}},
+		}),
+		DiffIteratorFactory: func() graveler.DiffIterator {
+			return gUtils.NewDiffIter([]graveler.Diff{
+				{Type: graveler.DiffTypeAdded, Key: graveler.Key("added"), Value: catalog.MustEntryToValue(&catalog.Entry{Address: "new", Size: 3})},
+				{Type: graveler.DiffTypeChanged, Key: graveler.Key("changed"), Value: catalog.MustEntryToValue(&catalog.Entry{Address: "newer", Size: 4})},
+				{Type: graveler.DiffTypeRemoved, Key: graveler.Key("removed")},
+			})
+		},
+	}
+	c := &catalog.Catalog{
+		Store: gravelerMock,
+	}
+	ctx := context.Background()
+
+	changes, hasMore, err := c.DiffUncommittedDetailed(ctx, "repo", "main", -1, "")
+	require.NoError(t, err)
+	require.False(t, hasMore)
+	require.Len(t, changes, 3)
+
+	require.Equal(t, catalog.DifferenceTypeAdded, changes[0].Type)
+	require.Nil(t, changes[0].Committed)
+	require.Equal(t, "new", changes[0].Staged.PhysicalAddress)
+
+	require.Equal(t, catalog.DifferenceTypeChanged, changes[1].Type)
+	require.Equal(t, "old", changes[1].Committed.PhysicalAddress)
+	require.Equal(t, "newer", changes[1].Staged.PhysicalAddress)
+
+	require.Equal(t, catalog.DifferenceTypeRemoved, changes[2].Type)
+	require.Equal(t, "gone", changes[2].Committed.PhysicalAddress)
+	require.Nil(t, changes[2].Staged)
+
+	changes, hasMore, err = c.DiffUncommittedDetailed(ctx, "repo", "main", 1, "added")
+	require.NoError(t, err)
+	require.True(t, hasMore)
+	require.Len(t, changes, 1)
+	require.Equal(t, "changed", changes[0].Path)
+}
+
+func TestCatalog_CommitsBetweenTags(t *testing.T) {
+	commits := []*graveler.CommitRecord{
+		{CommitID: "c4", Commit: &graveler.Commit{Parents: graveler.CommitParents{"c3"}}},
+		{CommitID: "c3", Commit: &graveler.Commit{Parents: graveler.CommitParents{"c2"}}},
+		{CommitID: "c2", Commit: &graveler.Commit{Parents: graveler.CommitParents{"c1"}}},
+		{CommitID: "c1", Commit: &graveler.Commit{}},
+		{CommitID: "c5", Commit: &graveler.Commit{Message: "unrelated"}},
+	}
+	tags := []*graveler.TagRecord{
+		{TagID: "v1", CommitID: "c1"},
+		{TagID: "v2", CommitID: "c4"},
+		{TagID: "v3", CommitID: "c5"},
+	}
+	gravelerMock := &catalog.FakeGraveler{
+		TagIteratorFactory:    catalog.NewFakeTagIteratorFactory(tags),
+		CommitIteratorFactory: func() graveler.CommitIterator { return gUtils.NewFakeCommitIterator(commits) },
+	}
+	c := &catalog.Catalog{
+		Store: gravelerMock,
+	}
+	ctx := context.Background()
+	commitIDs := func(logs []*catalog.CommitLog) []string {
+		var ids []string
+		for _, l := range logs {
+			ids = append(ids, l.Reference)
+		}
+		return ids
+	}
+
+	got, hasMore, err := c.CommitsBetweenTags(ctx, "repo", "v1", "v2", -1, "")
+	require.NoError(t, err)
+	require.Equal(t, []string{"c4", "c3", "c2"}, commitIDs(got))
+	require.False(t, hasMore)
+
+	got, hasMore, err = c.CommitsBetweenTags(ctx, "repo", "v1", "v2", 1, "c4")
+	require.NoError(t, err)
+	require.Equal(t, []string{"c3"}, commitIDs(got))
+	require.True(t, hasMore)
+
+	// c5 is not in the history of c4
+	_, _, err = c.CommitsBetweenTags(ctx, "repo", "v3", "v2", -1, "")
+	require.ErrorIs(t, err, catalog.ErrNotAncestor)
+
+	// c4 is a descendant of c1, not an ancestor
+	_, _, err = c.CommitsBetweenTags(ctx, "repo", "v2", "v1", -1, "")
+	require.ErrorIs(t, err, catalog.ErrNotAncestor)
+
+	_, _, err = c.CommitsBetweenTags(ctx, "repo", "v1", "v4", -1, "")
+	require.ErrorIs(t, err, graveler.ErrNotFound)
+}
+
+func TestCatalog_IsAncestor(t *testing.T) {
+	// c1 <- c2 <- c3 <- m, with b1 branching from c1 and merged by m, and u an unrelated root commit
+	commits := []*graveler.CommitRecord{
+		{CommitID: "m", Commit: &graveler.Commit{Message: "m", Parents: graveler.CommitParents{"c3", "b1"}}},
+		{CommitID: "c3", Commit: &graveler.Commit{Message: "c3", Parents: graveler.CommitParents{"c2"}}},
+		{CommitID: "b1", Commit: &graveler.Commit{Message: "b1", Parents: graveler.CommitParents{"c1"}}},
+		{CommitID: "c2", Commit: &graveler.Commit{Message: "c2", Parents: graveler.CommitParents{"c1"}}},
+		{CommitID: "c1", Commit: &graveler.Commit{Message: "c1"}},
+		{CommitID: "u", Commit: &graveler.Commit{Message: "u"}},
+	}
+	gravelerMock := &catalog.FakeGraveler{
+		CommitIteratorFactory: func() graveler.CommitIterator { return gUtils.NewFakeCommitIterator(commits) },
+	}
+	c := &catalog.Catalog{
+		Store: gravelerMock,
+	}
+	tests := []struct {
+		name       string
+		ancestor   string
+		descendant string
+		want       bool
+		wantErr    error
+	}{
+		{name: "first parent history", ancestor: "c1", descendant: "c3", want: true},
+		{name: "descendant", ancestor: "c3", descendant: "c1", want: false},
+		{name: "same commit", ancestor: "c2", descendant: "c2", want: true},
+		{name: "merged parent", ancestor: "b1", descendant: "m", want: true},
+		{name: "sibling", ancestor: "b1", descendant: "c3", want: false},
+		{name: "unrelated", ancestor: "u", descendant: "c3", want: false},
+		{name: "missing", ancestor: "c1", descendant: "missing", wantErr: graveler.ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := c.IsAncestor(context.Background(), "repo", tt.ancestor, tt.descendant)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCatalog_ListTagsByDate(t *testing.T) {
+	day := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	commits := []*graveler.CommitRecord{
+		{CommitID: "c1", Commit: &graveler.Commit{CreationDate: day}},
+		{CommitID: "c2", Commit: &graveler.Commit{CreationDate: day.Add(24 * time.Hour)}},
+		{CommitID: "c3", Commit: &graveler.Commit{CreationDate: day.Add(48 * time.Hour)}},
+	}
+	tags := []*graveler.TagRecord{
+		{TagID: "a", CommitID: "c2"},
+		{TagID: "b", CommitID: "c3"},
+		{TagID: "c", CommitID: "c1"},
+		{TagID: "d", CommitID: "c2"},
+	}
+	gravelerMock := &catalog.FakeGraveler{
+		TagIteratorFactory:    catalog.NewFakeTagIteratorFactory(tags),
+		CommitIteratorFactory: func() graveler.CommitIterator { return gUtils.NewFakeCommitIterator(commits) },
+	}
+	c := &catalog.Catalog{
+		Store: gravelerMock,
+	}
+	ctx := context.Background()
+	tagIDs := func(tags []*catalog.TagWithDate) []string {
+		var ids []string
+		for _, tag := range tags {
+			ids = append(ids, tag.ID)
+		}
+		return ids
+	}
+
+	got, hasMore, err := c.ListTagsByDate(ctx, "repo", -1, "", time.Time{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"b", "a", "d", "c"}, tagIDs(got))
+	require.False(t, hasMore)
+
+	got, hasMore, err = c.ListTagsByDate(ctx, "repo", 2, "", time.Time{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"b", "a"}, tagIDs(got))
+	require.True(t, hasMore)
+
+	last := got[len(got)-1]
+	got, hasMore, err = c.ListTagsByDate(ctx, "repo", 2, last.ID, last.CreationDate)
+	require.NoError(t, err)
+	require.Equal(t, []string{"d", "c"}, tagIDs(got))
+	require.False(t, hasMore)
+}
+
+func TestCatalog_LastMergeInto(t *testing.T) {
+	branches := []*graveler.BranchRecord{
+		{BranchID: "main", Branch: &graveler.Branch{CommitID: "c4"}},
+	}
+	tests := []struct {
+		name       string
+		commits    []*graveler.CommitRecord
+		wantCommit string
+		wantSource string
+		wantErr    error
+	}{
+		{
+			name: "merged",
+			commits: []*graveler.CommitRecord{
+				{CommitID: "c4", Commit: &graveler.Commit{Parents: graveler.CommitParents{"c3"}}},
+				{CommitID: "c3", Commit: &graveler.Commit{Parents: graveler.CommitParents{"c2", "f1"}}},
+				{CommitID: "c2", Commit: &graveler.Commit{Parents: graveler.CommitParents{"c1", "f0"}}},
+				{CommitID: "c1", Commit: &graveler.Commit{}},
+			},
+			wantCommit: "c3",
+			wantSource: "f1",
+		},
+		{
+			name: "no merges",
+			commits: []*graveler.CommitRecord{
+				{CommitID: "c4", Commit: &graveler.Commit{Parents: graveler.CommitParents{"c1"}}},
+				{CommitID: "c1", Commit: &graveler.Commit{}},
+			},
+			wantErr: graveler.ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gravelerMock := &catalog.FakeGraveler{
+				BranchIteratorFactory: gUtils.NewFakeBranchIteratorFactory(branches),
+				CommitIteratorFactory: func() graveler.CommitIterator { return gUtils.NewFakeCommitIterator(tt.commits) },
+			}
+			c := &catalog.Catalog{
+				Store: gravelerMock,
+			}
+			commit, source, err := c.LastMergeInto(context.Background(), "repo", "main")
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.wantCommit, commit.Reference)
+			require.Equal(t, tt.wantSource, source)
+		})
+	}
+}
+
+func TestCatalog_PathHistory(t *testing.T) {
+	commits := []*graveler.CommitRecord{
+		{CommitID: "c3", Commit: &graveler.Commit{Message: "update", Parents: graveler.CommitParents{"c2"}}},
+		{CommitID: "c2", Commit: &graveler.Commit{Message: "other", Parents: graveler.CommitParents{"c1"}}},
+		{CommitID: "c1", Commit: &graveler.Commit{Message: "add"}},
+	}
+	value := func(address string) *graveler.Value {
+		return catalog.MustEntryToValue(&catalog.Entry{Address: address})
+	}
+	gravelerMock := &catalog.FakeGraveler{
+		KeyValue: map[string]*graveler.Value{
+			"repo/c1/path": value("data/v1"),
+			"repo/c2/path": value("data/v1"),
+			"repo/c3/path": value("data/v2"),
+		},
+		CommitIteratorFactory: func() graveler.CommitIterator { return gUtils.NewFakeCommitIterator(commits) },
+	}
+	c := &catalog.Catalog{
+		Store: gravelerMock,
+	}
+	ctx := context.Background()
+
+	t.Run("last modified commit", func(t *testing.T) {
+		commit, err := c.LastModifiedCommit(ctx, "repo", "c3", "path")
+		require.NoError(t, err)
+		require.Equal(t, "c3", commit.Reference)
+
+		_, err = c.LastModifiedCommit(ctx, "repo", "c3", "missing")
+		require.ErrorIs(t, err, graveler.ErrNotFound)
+	})
+
+	t.Run("object provenance", func(t *testing.T) {
+		provenance, err := c.ObjectProvenance(ctx, "repo", "c3", "path")
+		require.NoError(t, err)
+		require.Equal(t, "c3", provenance.Commit.Reference)
+		require.Equal(t, "data/v2", provenance.Entry.PhysicalAddress)
+		require.Equal(t, 1, provenance.PriorVersions)
+	})
+
+	t.Run("path diff range", func(t *testing.T) {
+		diffs, err := c.PathDiffRange(ctx, "repo", "path", "c1", "c3")
+		require.NoError(t, err)
+		require.Len(t, diffs, 1)
+		require.Equal(t, "c3", diffs[0].Commit.Reference)
+		require.Equal(t, "data/v1", diffs[0].Before.PhysicalAddress)
+		require.Equal(t, "data/v2", diffs[0].After.PhysicalAddress)
+	})
+
+	t.Run("path diff range not ancestor", func(t *testing.T) {
+		_, err := c.PathDiffRange(ctx, "repo", "path", "unrelated", "c3")
+		require.ErrorIs(t, err, catalog.ErrNotAncestor)
+	})
+
+	t.Run("path diff range too long", func(t *testing.T) {
+		longHistory := make([]*graveler.CommitRecord, catalog.ProvenanceMaxCommits+1)
+		for i := range longHistory {
+			longHistory[i] = &graveler.CommitRecord{
+				CommitID: graveler.CommitID(fmt.Sprintf("c%d", len(longHistory)-i)),
+				Commit:   &graveler.Commit{Parents: graveler.CommitParents{graveler.CommitID(fmt.Sprintf("c%d", len(longHistory)-i-1))}},
+			}
+		}
+		c := &catalog.Catalog{
+			Store: &catalog.FakeGraveler{
+				KeyValue:              map[string]*graveler.Value{},
+				CommitIteratorFactory: func() graveler.CommitIterator { return gUtils.NewFakeCommitIterator(longHistory) },
+			},
+		}
+		_, err := c.PathDiffRange(ctx, "repo", "path", "c0", longHistory[0].CommitID.String())
+		require.ErrorIs(t, err, catalog.ErrHistoryTooLong)
+	})
+}
+
+func TestCatalog_WasDeleted(t *testing.T) {
+	branches := []*graveler.BranchRecord{
+		{BranchID: "main", Branch: &graveler.Branch{CommitID: "c3"}},
+	}
+	commits := []*graveler.CommitRecord{
+		{CommitID: "c3", Commit: &graveler.Commit{Message: "delete", Parents: graveler.CommitParents{"c2"}}},
+		{CommitID: "c2", Commit: &graveler.Commit{Message: "add", Parents: graveler.CommitParents{"c1"}}},
+		{CommitID: "c1", Commit: &graveler.Commit{Message: "init"}},
+	}
+	value := &graveler.Value{Identity: []byte("id"), Data: []byte("data")}
+	keyValue := map[string]*graveler.Value{
+		"repo/c2/deleted": value,
+		"repo/main/alive": value,
+		"repo/c3/staged":  value,
+	}
+	tests := []struct {
+		name        string
+		path        string
+		wantDeleted bool
+		wantCommit  string
+	}{
+		{name: "deleted", path: "deleted", wantDeleted: true, wantCommit: "c3"},
+		{name: "present", path: "alive", wantDeleted: false},
+		{name: "deleted on staging", path: "staged", wantDeleted: false},
+		{name: "never existed", path: "missing", wantDeleted: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gravelerMock := &catalog.FakeGraveler{
+				KeyValue:              keyValue,
+				BranchIteratorFactory: gUtils.NewFakeBranchIteratorFactory(branches),
+				CommitIteratorFactory: func() graveler.CommitIterator { return gUtils.NewFakeCommitIterator(commits) },
+			}
+			c := &catalog.Catalog{
+				Store: gravelerMock,
+			}
+			ctx := context.Background()
+			deleted, commit, err := c.WasDeleted(ctx, "repo", "main", tt.path)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantDeleted, deleted)
+			if !tt.wantDeleted {
+				require.Nil(t, commit)
+				return
+			}
+			require.NotNil(t, commit)
+			require.Equal(t, tt.wantCommit, commit.Reference)
+		})
+	}
+}
+
 func TestCatalog_PrepareGCUncommitted(t *testing.T) {
 	ctx := context.Background()
 	tests := []struct {
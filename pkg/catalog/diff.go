@@ -16,6 +16,34 @@ type Difference struct {
 	Type    DifferenceType `db:"diff_type"`
 }
 
+// UncommittedChange is an uncommitted change to a path, with the entry committed on the branch head and the staged
+// entry. Committed is nil for an added path and Staged is nil for a removed one.
+type UncommittedChange struct {
+	Path      string
+	Type      DifferenceType
+	Committed *DBEntry
+	Staged    *DBEntry
+}
+
+// CommitPreview describes the commit that committing a branch would create: the branch head it would be based on and
+// the uncommitted changes it would capture, up to DiffLimitMax of them.
+type CommitPreview struct {
+	BaseCommitID string
+	Changes      Differences
+	HasMore      bool
+}
+
+// DiffStats summarizes the differences between two references. BytesAdded is the total size of the entries on the
+// right side of added and changed paths, and BytesRemoved that of the entries on the left side of removed and changed
+// paths.
+type DiffStats struct {
+	Added        int
+	Changed      int
+	Removed      int
+	BytesAdded   int64
+	BytesRemoved int64
+}
+
 type DiffResultRecord struct {
 	TargetEntryNotInDirectBranch bool // the entry is reflected via lineage, NOT in the branch itself
 	Difference
@@ -3,9 +3,12 @@ package catalog
 import (
 	"github.com/treeverse/lakefs/pkg/graveler"
 	"github.com/treeverse/lakefs/pkg/ident"
+	"google.golang.org/protobuf/encoding/protowire"
 	"google.golang.org/protobuf/proto"
 )
 
+var entrySizeFieldNumber = (&Entry{}).ProtoReflect().Descriptor().Fields().ByName("size").Number()
+
 func ValueToEntry(value *graveler.Value) (*Entry, error) {
 	if value == nil {
 		return nil, nil
@@ -18,6 +21,35 @@ func ValueToEntry(value *graveler.Value) (*Entry, error) {
 	return &ent, nil
 }
 
+// ValueToEntrySize returns the size of the entry encoded in value. Only the size field is decoded, the other fields
+// are skipped without being parsed.
+func ValueToEntrySize(value *graveler.Value) (int64, error) {
+	data := value.Data
+	var size int64
+	for len(data) > 0 {
+		num, typ, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			return 0, protowire.ParseError(n)
+		}
+		data = data[n:]
+		if num == entrySizeFieldNumber && typ == protowire.VarintType {
+			v, n := protowire.ConsumeVarint(data)
+			if n < 0 {
+				return 0, protowire.ParseError(n)
+			}
+			size = int64(v) // last value wins, as when unmarshalling
+			data = data[n:]
+			continue
+		}
+		n = protowire.ConsumeFieldValue(num, typ, data)
+		if n < 0 {
+			return 0, protowire.ParseError(n)
+		}
+		data = data[n:]
+	}
+	return size, nil
+}
+
 func EntryToValue(entry *Entry) (*graveler.Value, error) {
 	// marshal data using pb
 	data, err := proto.Marshal(entry)
@@ -36,3 +36,28 @@ func TestEntryToValueAndBack(t *testing.T) {
 		t.Fatal("Entry convert to value and back failed:", diff)
 	}
 }
+
+func TestValueToEntrySize(t *testing.T) {
+	entries := []*Entry{
+		{Address: "empty"},
+		{
+			Address:      "entry1",
+			LastModified: timestamppb.New(time.Now()),
+			Size:         99,
+			ETag:         "123456789",
+			Metadata:     map[string]string{"key9": "value9", "key1": "value1"},
+			ContentType:  "text/plain",
+		},
+		{Address: "large", Size: 1 << 40},
+	}
+	for _, entry := range entries {
+		val := MustEntryToValue(entry)
+		size, err := ValueToEntrySize(val)
+		if err != nil {
+			t.Fatalf("ValueToEntrySize(%s) error: %s", entry.Address, err)
+		}
+		if size != entry.Size {
+			t.Errorf("ValueToEntrySize(%s) = %d, expected %d", entry.Address, size, entry.Size)
+		}
+	}
+}
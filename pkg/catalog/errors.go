@@ -19,4 +19,23 @@ var (
 
 	ErrFeatureNotSupported = errors.New("feature not supported")
 	ErrNonEmptyRepository  = errors.New("non empty repository")
+	ErrStaleOperation      = fmt.Errorf("stale operation: %w", graveler.ErrPreconditionFailed)
+	ErrNotAncestor         = fmt.Errorf("not an ancestor: %w", graveler.ErrInvalidValue)
+	ErrHistoryTooLong      = graveler.ErrHistoryTooLong
+
+	ErrChecksumNotVerifiable = errors.New("checksum not verifiable")
 )
+
+// DeleteBranchError is the failure to delete one of the branches passed to DeleteBranches
+type DeleteBranchError struct {
+	Branch string
+	Err    error
+}
+
+func (d *DeleteBranchError) Error() string {
+	return fmt.Sprintf("%s: %s", d.Branch, d.Err.Error())
+}
+
+func (d *DeleteBranchError) Unwrap() error {
+	return d.Err
+}
@@ -11,15 +11,25 @@ import (
 
 type FakeGraveler struct {
 	graveler.VersionController
-	KeyValue                   map[string]*graveler.Value
-	Err                        error
-	ListIteratorFactory        func() graveler.ValueIterator
-	DiffIteratorFactory        func() graveler.DiffIterator
-	RepositoryIteratorFactory  func() graveler.RepositoryIterator
-	BranchIteratorFactory      func() graveler.BranchIterator
-	TagIteratorFactory         func() graveler.TagIterator
-	LinkAddressIteratorFactory func() graveler.LinkAddressIterator
-	hooks                      graveler.HooksHandler
+	KeyValue                       map[string]*graveler.Value
+	Err                            error
+	DeleteErr                      error
+	ListIteratorFactory            func() graveler.ValueIterator
+	DiffIteratorFactory            func() graveler.DiffIterator
+	UncommittedDiffIteratorFactory func() graveler.DiffIterator
+	RefsDiffIteratorFactory        func(left, right graveler.Ref) graveler.DiffIterator
+	RepositoryIteratorFactory      func() graveler.RepositoryIterator
+	BranchIteratorFactory          func() graveler.BranchIterator
+	TagIteratorFactory             func() graveler.TagIterator
+	CommitIteratorFactory          func() graveler.CommitIterator
+	LinkAddressIteratorFactory     func() graveler.LinkAddressIterator
+	MaintenanceReason              string
+	DefaultBranchID                graveler.BranchID
+	CommitKeysID                   graveler.CommitID
+	CommittedKeys                  []graveler.Key
+	CommitKeysErr                  error
+	ResetKeys                      []graveler.Key
+	hooks                          graveler.HooksHandler
 }
 
 func (g *FakeGraveler) StageObject(ctx context.Context, stagingToken string, object graveler.ValueRecord) error {
@@ -107,6 +117,14 @@ func (g *FakeGraveler) GetByCommitID(ctx context.Context, repository *graveler.R
 	return g.Get(ctx, repository, graveler.Ref(commitID), key)
 }
 
+func (g *FakeGraveler) GetByResolvedRef(ctx context.Context, repository *graveler.RepositoryRecord, reference *graveler.ResolvedRef, key graveler.Key, opts ...graveler.GetOptionsFunc) (*graveler.Value, error) {
+	ref := graveler.Ref(reference.CommitID)
+	if reference.BranchID != "" {
+		ref = graveler.Ref(reference.BranchID)
+	}
+	return g.Get(ctx, repository, ref, key, opts...)
+}
+
 func (g *FakeGraveler) Set(_ context.Context, repository *graveler.RepositoryRecord, branchID graveler.BranchID, key graveler.Key, value graveler.Value, _ ...graveler.SetOptionsFunc) error {
 	if g.Err != nil {
 		return g.Err
@@ -116,11 +134,35 @@ func (g *FakeGraveler) Set(_ context.Context, repository *graveler.RepositoryRec
 	return nil
 }
 
+func (g *FakeGraveler) SetBatch(_ context.Context, repository *graveler.RepositoryRecord, branchID graveler.BranchID, records []graveler.ValueRecord, _ ...graveler.SetOptionsFunc) error {
+	if g.Err != nil {
+		return g.Err
+	}
+	for _, record := range records {
+		k := fakeGravelerBuildKey(repository.RepositoryID, graveler.Ref(branchID.String()), record.Key)
+		g.KeyValue[k] = record.Value
+	}
+	return nil
+}
+
 func (g *FakeGraveler) Delete(ctx context.Context, repository *graveler.RepositoryRecord, branchID graveler.BranchID, key graveler.Key, _ ...graveler.SetOptionsFunc) error {
+	if g.Err != nil {
+		return g.Err
+	}
+	if g.DeleteErr != nil {
+		return g.DeleteErr
+	}
+	delete(g.KeyValue, fakeGravelerBuildKey(repository.RepositoryID, graveler.Ref(branchID.String()), key))
 	return nil
 }
 
 func (g *FakeGraveler) DeleteBatch(ctx context.Context, repository *graveler.RepositoryRecord, branchID graveler.BranchID, keys []graveler.Key, _ ...graveler.SetOptionsFunc) error {
+	if g.Err != nil {
+		return g.Err
+	}
+	for _, key := range keys {
+		delete(g.KeyValue, fakeGravelerBuildKey(repository.RepositoryID, graveler.Ref(branchID.String()), key))
+	}
 	return nil
 }
 
@@ -131,8 +173,24 @@ func (g *FakeGraveler) List(_ context.Context, _ *graveler.RepositoryRecord, _ g
 	return g.ListIteratorFactory(), nil
 }
 
+func (g *FakeGraveler) ListByResolvedRef(ctx context.Context, repository *graveler.RepositoryRecord, reference *graveler.ResolvedRef, batchSize int) (graveler.ValueIterator, error) {
+	return g.List(ctx, repository, graveler.Ref(reference.CommitID), batchSize)
+}
+
 func (g *FakeGraveler) GetRepository(ctx context.Context, repositoryID graveler.RepositoryID) (*graveler.RepositoryRecord, error) {
-	return &graveler.RepositoryRecord{RepositoryID: repositoryID}, nil
+	return &graveler.RepositoryRecord{RepositoryID: repositoryID, Repository: &graveler.Repository{MaintenanceReason: g.MaintenanceReason, DefaultBranchID: g.DefaultBranchID}}, nil
+}
+
+func (g *FakeGraveler) SetRepositoryMaintenance(_ context.Context, _ *graveler.RepositoryRecord, reason string) error {
+	if g.Err != nil {
+		return g.Err
+	}
+	g.MaintenanceReason = reason
+	return nil
+}
+
+func (g *FakeGraveler) GetRepositoryMetadata(_ context.Context, _ graveler.RepositoryID) (graveler.RepositoryMetadata, error) {
+	return nil, nil
 }
 
 func (g *FakeGraveler) CreateRepository(ctx context.Context, repositoryID graveler.RepositoryID, storageNamespace graveler.StorageNamespace, branchID graveler.BranchID, readOnly bool) (*graveler.RepositoryRecord, error) {
@@ -151,11 +209,19 @@ func (g *FakeGraveler) DeleteRepository(ctx context.Context, repositoryID gravel
 }
 
 func (g *FakeGraveler) CreateBranch(ctx context.Context, repository *graveler.RepositoryRecord, branchID graveler.BranchID, ref graveler.Ref, _ ...graveler.SetOptionsFunc) (*graveler.Branch, error) {
-	panic("implement me")
+	// TODO(nopcoder): ref is resolved as a branch only
+	source, err := g.GetBranch(ctx, repository, graveler.BranchID(ref))
+	if err != nil {
+		return nil, err
+	}
+	return &graveler.Branch{CommitID: source.CommitID}, nil
 }
 
 func (g *FakeGraveler) UpdateBranch(ctx context.Context, repository *graveler.RepositoryRecord, branchID graveler.BranchID, ref graveler.Ref, _ ...graveler.SetOptionsFunc) (*graveler.Branch, error) {
-	panic("implement me")
+	if g.Err != nil {
+		return nil, g.Err
+	}
+	return &graveler.Branch{CommitID: graveler.CommitID(ref)}, nil
 }
 
 func (g *FakeGraveler) GetBranch(ctx context.Context, repository *graveler.RepositoryRecord, branchID graveler.BranchID) (*graveler.Branch, error) {
@@ -179,7 +245,22 @@ func (g *FakeGraveler) GetBranch(ctx context.Context, repository *graveler.Repos
 }
 
 func (g *FakeGraveler) GetTag(ctx context.Context, repository *graveler.RepositoryRecord, tagID graveler.TagID) (*graveler.CommitID, error) {
-	panic("implement me")
+	if g.Err != nil {
+		return nil, g.Err
+	}
+	it := g.TagIteratorFactory()
+	it.SeekGE(tagID)
+	if it.Err() != nil {
+		return nil, it.Err()
+	}
+	if !it.Next() {
+		return nil, graveler.ErrNotFound
+	}
+	tag := it.Value()
+	if tag.TagID != tagID {
+		return nil, graveler.ErrNotFound
+	}
+	return &tag.CommitID, nil
 }
 
 func (g *FakeGraveler) CreateTag(ctx context.Context, repository *graveler.RepositoryRecord, tagID graveler.TagID, commitID graveler.CommitID, _ ...graveler.SetOptionsFunc) error {
@@ -198,7 +279,10 @@ func (g *FakeGraveler) ListTags(ctx context.Context, repository *graveler.Reposi
 }
 
 func (g *FakeGraveler) Log(ctx context.Context, repository *graveler.RepositoryRecord, commitID graveler.CommitID, firstParent bool, since *time.Time) (graveler.CommitIterator, error) {
-	panic("implement me")
+	if g.Err != nil {
+		return nil, g.Err
+	}
+	return g.CommitIteratorFactory(), nil
 }
 
 func (g *FakeGraveler) ListBranches(_ context.Context, _ *graveler.RepositoryRecord) (graveler.BranchIterator, error) {
@@ -209,13 +293,41 @@ func (g *FakeGraveler) ListBranches(_ context.Context, _ *graveler.RepositoryRec
 }
 
 func (g *FakeGraveler) DeleteBranch(ctx context.Context, repository *graveler.RepositoryRecord, branchID graveler.BranchID, _ ...graveler.SetOptionsFunc) error {
-	panic("implement me")
+	if g.Err != nil {
+		return g.Err
+	}
+	if repository.DefaultBranchID == branchID && branchID != "" {
+		return graveler.ErrDeleteDefaultBranch
+	}
+	if g.BranchIteratorFactory != nil {
+		if _, err := g.GetBranch(ctx, repository, branchID); err != nil {
+			return err
+		}
+	}
+	prefix := fakeGravelerBuildKey(repository.RepositoryID, graveler.Ref(branchID.String()), nil)
+	for k := range g.KeyValue {
+		if strings.HasPrefix(k, prefix) {
+			delete(g.KeyValue, k)
+		}
+	}
+	return nil
 }
 
 func (g *FakeGraveler) Commit(ctx context.Context, repository *graveler.RepositoryRecord, branchID graveler.BranchID, _ graveler.CommitParams, _ ...graveler.SetOptionsFunc) (graveler.CommitID, error) {
 	panic("implement me")
 }
 
+func (g *FakeGraveler) CommitKeys(_ context.Context, _ *graveler.RepositoryRecord, _ graveler.BranchID, keys []graveler.Key, _ graveler.CommitParams, _ ...graveler.SetOptionsFunc) (graveler.CommitID, error) {
+	if g.Err != nil {
+		return "", g.Err
+	}
+	if g.CommitKeysErr != nil {
+		return "", g.CommitKeysErr
+	}
+	g.CommittedKeys = keys
+	return g.CommitKeysID, nil
+}
+
 func (g *FakeGraveler) CreateCommitRecord(ctx context.Context, repository *graveler.RepositoryRecord, commitID graveler.CommitID, commit graveler.Commit, opts ...graveler.SetOptionsFunc) error {
 	panic("implement me")
 }
@@ -229,19 +341,43 @@ func (g *FakeGraveler) WriteMetaRange(ctx context.Context, repository *graveler.
 }
 
 func (g *FakeGraveler) GetCommit(ctx context.Context, repository *graveler.RepositoryRecord, commitID graveler.CommitID) (*graveler.Commit, error) {
-	panic("implement me")
+	if g.Err != nil {
+		return nil, g.Err
+	}
+	it := g.CommitIteratorFactory()
+	defer it.Close()
+	for it.Next() {
+		if commit := it.Value(); commit.CommitID == commitID {
+			return commit.Commit, nil
+		}
+	}
+	if it.Err() != nil {
+		return nil, it.Err()
+	}
+	return nil, graveler.ErrNotFound
 }
 
 func (g *FakeGraveler) Dereference(ctx context.Context, repository *graveler.RepositoryRecord, ref graveler.Ref) (*graveler.ResolvedRef, error) {
-	panic("implement me")
+	if g.Err != nil {
+		return nil, g.Err
+	}
+	// TODO(nopcoder): ref is resolved as a commit ID only
+	return &graveler.ResolvedRef{
+		Type:         graveler.ReferenceTypeCommit,
+		BranchRecord: graveler.BranchRecord{Branch: &graveler.Branch{CommitID: graveler.CommitID(ref)}},
+	}, nil
 }
 
 func (g *FakeGraveler) Reset(ctx context.Context, repository *graveler.RepositoryRecord, branchID graveler.BranchID, _ ...graveler.SetOptionsFunc) error {
 	panic("implement me")
 }
 
-func (g *FakeGraveler) ResetKey(ctx context.Context, repository *graveler.RepositoryRecord, branchID graveler.BranchID, key graveler.Key, _ ...graveler.SetOptionsFunc) error {
-	panic("implement me")
+func (g *FakeGraveler) ResetKey(_ context.Context, _ *graveler.RepositoryRecord, _ graveler.BranchID, key graveler.Key, _ ...graveler.SetOptionsFunc) error {
+	if g.Err != nil {
+		return g.Err
+	}
+	g.ResetKeys = append(g.ResetKeys, key)
+	return nil
 }
 
 func (g *FakeGraveler) ResetPrefix(ctx context.Context, repository *graveler.RepositoryRecord, branchID graveler.BranchID, key graveler.Key, _ ...graveler.SetOptionsFunc) error {
@@ -257,20 +393,98 @@ func (g *FakeGraveler) Merge(ctx context.Context, repository *graveler.Repositor
 }
 
 func (g *FakeGraveler) FindMergeBase(ctx context.Context, repository *graveler.RepositoryRecord, from graveler.Ref, to graveler.Ref) (*graveler.CommitRecord, *graveler.CommitRecord, *graveler.Commit, error) {
-	panic("implement me")
+	if g.Err != nil {
+		return nil, nil, nil, g.Err
+	}
+	// TODO(nopcoder): refs are resolved as commit IDs only
+	fromCommit, err := g.GetCommit(ctx, repository, graveler.CommitID(from))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	toCommit, err := g.GetCommit(ctx, repository, graveler.CommitID(to))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	// the merge base is the first commit reachable from "to" found walking back from "from"
+	reachable := make(map[graveler.CommitID]struct{})
+	queue := []graveler.CommitID{graveler.CommitID(to)}
+	for len(queue) > 0 {
+		commitID := queue[0]
+		queue = queue[1:]
+		if _, ok := reachable[commitID]; ok {
+			continue
+		}
+		reachable[commitID] = struct{}{}
+		commit, err := g.GetCommit(ctx, repository, commitID)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		queue = append(queue, commit.Parents...)
+	}
+	queue = []graveler.CommitID{graveler.CommitID(from)}
+	for len(queue) > 0 {
+		commitID := queue[0]
+		queue = queue[1:]
+		commit, err := g.GetCommit(ctx, repository, commitID)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if _, ok := reachable[commitID]; ok {
+			return &graveler.CommitRecord{CommitID: graveler.CommitID(from), Commit: fromCommit},
+				&graveler.CommitRecord{CommitID: graveler.CommitID(to), Commit: toCommit},
+				commit, nil
+		}
+		queue = append(queue, commit.Parents...)
+	}
+	return nil, nil, nil, graveler.ErrNoMergeBase
+}
+
+func (g *FakeGraveler) IsAncestor(ctx context.Context, repository *graveler.RepositoryRecord, ancestor graveler.Ref, descendant graveler.Ref) (bool, error) {
+	if g.Err != nil {
+		return false, g.Err
+	}
+	// TODO(nopcoder): refs are resolved as commit IDs only
+	if _, err := g.GetCommit(ctx, repository, graveler.CommitID(ancestor)); err != nil {
+		return false, err
+	}
+	visited := make(map[graveler.CommitID]struct{})
+	queue := []graveler.CommitID{graveler.CommitID(descendant)}
+	for len(queue) > 0 {
+		commitID := queue[0]
+		queue = queue[1:]
+		if commitID == graveler.CommitID(ancestor) {
+			return true, nil
+		}
+		if _, ok := visited[commitID]; ok {
+			continue
+		}
+		visited[commitID] = struct{}{}
+		commit, err := g.GetCommit(ctx, repository, commitID)
+		if err != nil {
+			return false, err
+		}
+		queue = append(queue, commit.Parents...)
+	}
+	return false, nil
 }
 
 func (g *FakeGraveler) DiffUncommitted(ctx context.Context, repository *graveler.RepositoryRecord, branchID graveler.BranchID) (graveler.DiffIterator, error) {
 	if g.Err != nil {
 		return nil, g.Err
 	}
+	if g.UncommittedDiffIteratorFactory != nil {
+		return g.UncommittedDiffIteratorFactory(), nil
+	}
 	return g.DiffIteratorFactory(), nil
 }
 
-func (g *FakeGraveler) Diff(_ context.Context, _ *graveler.RepositoryRecord, _, _ graveler.Ref) (graveler.DiffIterator, error) {
+func (g *FakeGraveler) Diff(_ context.Context, _ *graveler.RepositoryRecord, left, right graveler.Ref) (graveler.DiffIterator, error) {
 	if g.Err != nil {
 		return nil, g.Err
 	}
+	if g.RefsDiffIteratorFactory != nil {
+		return g.RefsDiffIteratorFactory(left, right), nil
+	}
 	return g.DiffIteratorFactory(), nil
 }
 
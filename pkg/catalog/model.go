@@ -25,6 +25,16 @@ type Repository struct {
 	ReadOnly         bool
 }
 
+// RepositoryStats holds the number of branches of a repository, and as of its default branch commit CommitID, the
+// number of commits reachable from it and the number and total size of the objects it holds.
+type RepositoryStats struct {
+	Branches int
+	CommitID string
+	Commits  int64
+	Objects  int64
+	Bytes    int64
+}
+
 type DBEntry struct {
 	CommonLevel     bool
 	Path            string
@@ -50,16 +60,81 @@ type CommitLog struct {
 	Version      CommitVersion
 }
 
+// PathVersionDiff is a change to a single path made by a commit. Before is nil when the commit added
+// the path and After is nil when the commit removed it.
+type PathVersionDiff struct {
+	Commit *CommitLog
+	Before *DBEntry
+	After  *DBEntry
+}
+
+// Provenance is the origin of the current version of an object. Commit introduced Entry and PriorVersions counts
+// the versions of the path that came before it.
+type Provenance struct {
+	Entry         *DBEntry
+	Commit        *CommitLog
+	PriorVersions int
+}
+
+// EntryWithCommit is a committed entry along with the commit that last modified it.
+// Commit is nil when the modifying commit was not found within the history walked.
+type EntryWithCommit struct {
+	DBEntry
+	Commit *CommitLog
+}
+
+// TreeShape describes the layout of paths under a prefix, using DefaultPathDelimiter as directory separator.
+// Sampled is set when only the first TreeShapeMaxEntries entries were read.
+type TreeShape struct {
+	EntryCount      int
+	MaxDepth        int
+	MaxFanOut       int
+	MaxFanOutPrefix string
+	Sampled         bool
+}
+
+// EntryOrError is a single result of a parallel scan: an entry, or the error that ended the scan
+type EntryOrError struct {
+	Entry *DBEntry
+	Err   error
+}
+
 type Branch struct {
 	Name      string
 	Reference string
 }
 
+// BranchActivity is a branch with the creation date of its head commit
+type BranchActivity struct {
+	Branch
+	LastCommitDate time.Time
+}
+
 type Tag struct {
 	ID       string
 	CommitID string
 }
 
+const (
+	PatchTypeAdded   = "added"
+	PatchTypeChanged = "changed"
+	PatchTypeRemoved = "removed"
+)
+
+// PatchRecord is a single change of a commit patch, as written by ExportCommitPatch. Type is one of the PatchType
+// values and Entry is the new entry of Path, nil when the path was removed.
+type PatchRecord struct {
+	Path  string   `json:"path"`
+	Type  string   `json:"type"`
+	Entry *DBEntry `json:"entry,omitempty"`
+}
+
+// TagWithDate is a tag with the creation date of the commit it points to
+type TagWithDate struct {
+	Tag
+	CreationDate time.Time
+}
+
 type PullRequest struct {
 	ID                string
 	Title             string
@@ -329,7 +329,8 @@ type Config struct {
 		Background struct {
 			RateLimit int `mapstructure:"rate_limit"`
 		} `mapstructure:"background"`
-		MaxBatchDelay time.Duration `mapstructure:"max_batch_delay"`
+		MaxBatchDelay   time.Duration `mapstructure:"max_batch_delay"`
+		CommitRateLimit float64       `mapstructure:"commit_rate_limit"`
 	} `mapstructure:"graveler"`
 	Gateways struct {
 		S3 struct {
@@ -10,7 +10,10 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
-const storePartitionKey = "multiparts"
+const (
+	storePartitionKey = "multiparts"
+	ListLimitMax      = 1000
+)
 
 type Metadata map[string]string
 
@@ -33,6 +36,12 @@ type Tracker interface {
 	Create(ctx context.Context, multipart Upload) error
 	Get(ctx context.Context, uploadID string) (*Upload, error)
 	Delete(ctx context.Context, uploadID string) error
+	// List returns up to amount uploads created before olderThan, ordered by upload ID and starting after the
+	// upload ID 'after'. The boolean result reports whether there are more uploads to list.
+	List(ctx context.Context, olderThan time.Time, amount int, after string) ([]*Upload, bool, error)
+	// DeleteExpired deletes the tracking of all uploads created before olderThan and returns how many were
+	// deleted. It does not abort the uploads on the object store.
+	DeleteExpired(ctx context.Context, olderThan time.Time) (int, error)
 }
 
 type tracker struct {
@@ -42,6 +51,7 @@ type tracker struct {
 var (
 	ErrMultipartUploadNotFound = errors.New("multipart upload not found")
 	ErrInvalidUploadID         = errors.New("invalid upload id")
+	ErrInvalidPath             = errors.New("invalid path")
 )
 
 func NewTracker(store kv.Store) Tracker {
@@ -76,6 +86,9 @@ func (m *tracker) Create(ctx context.Context, multipart Upload) error {
 	if multipart.UploadID == "" {
 		return ErrInvalidUploadID
 	}
+	if multipart.Path == "" {
+		return ErrInvalidPath
+	}
 	return kv.SetMsgIf(ctx, m.store, storePartitionKey, []byte(multipart.UploadID), protoFromMultipart(&multipart), nil)
 }
 
@@ -105,3 +118,57 @@ func (m *tracker) Delete(ctx context.Context, uploadID string) error {
 
 	return m.store.Delete(ctx, []byte(storePartitionKey), key)
 }
+
+func (m *tracker) List(ctx context.Context, olderThan time.Time, amount int, after string) ([]*Upload, bool, error) {
+	if amount <= 0 || amount > ListLimitMax {
+		amount = ListLimitMax
+	}
+	it, err := kv.NewPrimaryIterator(ctx, m.store, (&UploadData{}).ProtoReflect().Type(), storePartitionKey, []byte(""), kv.IteratorOptionsAfter([]byte(after)))
+	if err != nil {
+		return nil, false, err
+	}
+	defer it.Close()
+
+	var uploads []*Upload
+	for it.Next() {
+		data := it.Entry().Value.(*UploadData)
+		if !data.CreationDate.AsTime().Before(olderThan) {
+			continue
+		}
+		if len(uploads) == amount {
+			return uploads, true, nil
+		}
+		uploads = append(uploads, multipartFromProto(data))
+	}
+	if err := it.Err(); err != nil {
+		return nil, false, err
+	}
+	return uploads, false, nil
+}
+
+func (m *tracker) DeleteExpired(ctx context.Context, olderThan time.Time) (int, error) {
+	it, err := kv.NewPrimaryIterator(ctx, m.store, (&UploadData{}).ProtoReflect().Type(), storePartitionKey, []byte(""), kv.IteratorOptionsFrom([]byte("")))
+	if err != nil {
+		return 0, err
+	}
+	// collect the keys first, deleting while scanning the partition is not safe on all stores
+	var keys [][]byte
+	for it.Next() {
+		ent := it.Entry()
+		if ent.Value.(*UploadData).CreationDate.AsTime().Before(olderThan) {
+			keys = append(keys, ent.Key)
+		}
+	}
+	err = it.Err()
+	it.Close()
+	if err != nil {
+		return 0, err
+	}
+
+	for i, key := range keys {
+		if err := m.store.Delete(ctx, []byte(storePartitionKey), key); err != nil {
+			return i, err
+		}
+	}
+	return len(keys), nil
+}
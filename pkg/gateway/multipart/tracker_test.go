@@ -0,0 +1,101 @@
+package multipart_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+	"github.com/treeverse/lakefs/pkg/gateway/multipart"
+	"github.com/treeverse/lakefs/pkg/kv"
+	"github.com/treeverse/lakefs/pkg/kv/kvtest"
+)
+
+func TestTracker_ListDeleteExpired(t *testing.T) {
+	ctx := context.Background()
+	tracker := multipart.NewTracker(kvtest.GetStore(ctx, t))
+
+	now := time.Now().UTC().Truncate(time.Second)
+	for i := 0; i < 5; i++ {
+		creation := now
+		if i%2 == 0 {
+			creation = now.Add(-48 * time.Hour)
+		}
+		err := tracker.Create(ctx, multipart.Upload{
+			UploadID:        fmt.Sprintf("upload%d", i),
+			Path:            fmt.Sprintf("path%d", i),
+			CreationDate:    creation,
+			PhysicalAddress: fmt.Sprintf("address%d", i),
+		})
+		if err != nil {
+			t.Fatalf("Create(upload%d) failed: %s", i, err)
+		}
+	}
+	olderThan := now.Add(-24 * time.Hour)
+
+	// list in pages of two expired uploads
+	var ids []string
+	after := ""
+	for {
+		uploads, hasMore, err := tracker.List(ctx, olderThan, 2, after)
+		if err != nil {
+			t.Fatalf("List() failed: %s", err)
+		}
+		for _, u := range uploads {
+			ids = append(ids, u.UploadID)
+			after = u.UploadID
+		}
+		if !hasMore {
+			break
+		}
+	}
+	if diff := deep.Equal(ids, []string{"upload0", "upload2", "upload4"}); diff != nil {
+		t.Fatalf("List() expired uploads diff: %s", diff)
+	}
+
+	deleted, err := tracker.DeleteExpired(ctx, olderThan)
+	if err != nil {
+		t.Fatalf("DeleteExpired() failed: %s", err)
+	}
+	const expectedDeleted = 3
+	if deleted != expectedDeleted {
+		t.Fatalf("DeleteExpired() deleted %d, expected %d", deleted, expectedDeleted)
+	}
+
+	uploads, _, err := tracker.List(ctx, now.Add(time.Hour), 0, "")
+	if err != nil {
+		t.Fatalf("List() failed: %s", err)
+	}
+	ids = nil
+	for _, u := range uploads {
+		ids = append(ids, u.UploadID)
+	}
+	if diff := deep.Equal(ids, []string{"upload1", "upload3"}); diff != nil {
+		t.Fatalf("List() remaining uploads diff: %s", diff)
+	}
+}
+
+func TestTracker_InvalidInput(t *testing.T) {
+	ctx := context.Background()
+	tracker := multipart.NewTracker(kvtest.GetStore(ctx, t))
+
+	err := tracker.Create(ctx, multipart.Upload{Path: "path", CreationDate: time.Now()})
+	if !errors.Is(err, multipart.ErrInvalidUploadID) {
+		t.Errorf("Create() without upload id err=%v, expected %s", err, multipart.ErrInvalidUploadID)
+	}
+	err = tracker.Create(ctx, multipart.Upload{UploadID: "upload", CreationDate: time.Now()})
+	if !errors.Is(err, multipart.ErrInvalidPath) {
+		t.Errorf("Create() without path err=%v, expected %s", err, multipart.ErrInvalidPath)
+	}
+	if _, err := tracker.Get(ctx, "upload"); !errors.Is(err, kv.ErrNotFound) {
+		t.Errorf("Get() after invalid create err=%v, expected %s", err, kv.ErrNotFound)
+	}
+	if _, err := tracker.Get(ctx, ""); !errors.Is(err, multipart.ErrInvalidUploadID) {
+		t.Errorf("Get() without upload id err=%v, expected %s", err, multipart.ErrInvalidUploadID)
+	}
+	if err := tracker.Delete(ctx, ""); !errors.Is(err, multipart.ErrInvalidUploadID) {
+		t.Errorf("Delete() without upload id err=%v, expected %s", err, multipart.ErrInvalidUploadID)
+	}
+}
@@ -164,3 +164,42 @@ func (f *FilterTombstoneIterator) Err() error {
 func (f *FilterTombstoneIterator) Close() {
 	f.iter.Close()
 }
+
+// FilterKeysIterator wraps a value iterator and passes only records of the given keys.
+type FilterKeysIterator struct {
+	iter ValueIterator
+	keys map[string]struct{}
+}
+
+func NewFilterKeysIterator(iter ValueIterator, keys []Key) *FilterKeysIterator {
+	keysSet := make(map[string]struct{}, len(keys))
+	for _, key := range keys {
+		keysSet[key.String()] = struct{}{}
+	}
+	return &FilterKeysIterator{iter: iter, keys: keysSet}
+}
+
+func (f *FilterKeysIterator) Next() bool {
+	for f.iter.Next() {
+		if _, ok := f.keys[f.iter.Value().Key.String()]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *FilterKeysIterator) SeekGE(id Key) {
+	f.iter.SeekGE(id)
+}
+
+func (f *FilterKeysIterator) Value() *ValueRecord {
+	return f.iter.Value()
+}
+
+func (f *FilterKeysIterator) Err() error {
+	return f.iter.Err()
+}
+
+func (f *FilterKeysIterator) Close() {
+	f.iter.Close()
+}
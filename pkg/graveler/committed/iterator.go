@@ -78,6 +78,12 @@ func (rvi *iterator) Next() bool {
 	if rvi.err != nil {
 		return false
 	}
+	select {
+	case <-rvi.ctx.Done():
+		rvi.err = rvi.ctx.Err()
+		return false
+	default:
+	}
 	if rvi.beforeRange {
 		rvi.beforeRange = false
 		return true
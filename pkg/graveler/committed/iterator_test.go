@@ -2,6 +2,7 @@ package committed_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"testing"
 
@@ -253,3 +254,29 @@ func TestIterator(t *testing.T) {
 		})
 	}
 }
+
+func TestIteratorCancelContext(t *testing.T) {
+	namespace := committed.Namespace("ns")
+	keys := makeKeys("a1", "a2", "a3")
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	manager := mock.NewMockRangeManager(ctrl)
+	manager.EXPECT().
+		NewRangeIterator(gomock.Any(), gomock.Eq(namespace), committed.ID("a3")).
+		Return(makeRangeIterator(keys), nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	rangesIt := testutil.NewCommittedValueIteratorFake(makeRangeRecords([]rangeKeys{{Name: "a3", Keys: keys}}))
+	it := committed.NewIterator(ctx, manager, namespace, rangesIt)
+	defer it.Close()
+
+	// range header and first value
+	require.True(t, it.Next())
+	require.True(t, it.Next())
+	cancel()
+	require.False(t, it.Next())
+	if err := it.Err(); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Err() returned %v, should return context.Canceled", err)
+	}
+}
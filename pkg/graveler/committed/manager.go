@@ -182,6 +182,48 @@ func (c *committedManager) Diff(ctx context.Context, ns graveler.StorageNamespac
 	return NewDiffValueIterator(ctx, leftIt, rightIt), nil
 }
 
+func (c *committedManager) EstimateDiffSize(ctx context.Context, ns graveler.StorageNamespace, left, right graveler.MetaRangeID) (int, error) {
+	leftRanges, err := c.rangeCounts(ctx, ns, left)
+	if err != nil {
+		return 0, err
+	}
+	rightRanges, err := c.rangeCounts(ctx, ns, right)
+	if err != nil {
+		return 0, err
+	}
+	// a changed entry appears in a range on each side, count each side once and take the larger
+	var leftOnly, rightOnly int64
+	for id, count := range leftRanges {
+		if _, ok := rightRanges[id]; !ok {
+			leftOnly += count
+		}
+	}
+	for id, count := range rightRanges {
+		if _, ok := leftRanges[id]; !ok {
+			rightOnly += count
+		}
+	}
+	return int(max(leftOnly, rightOnly)), nil
+}
+
+// rangeCounts returns the number of records in each range of the metaRange, reading only range headers
+func (c *committedManager) rangeCounts(ctx context.Context, ns graveler.StorageNamespace, id graveler.MetaRangeID) (map[ID]int64, error) {
+	it, err := c.metaRangeManager.NewMetaRangeIterator(ctx, ns, id)
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+	counts := make(map[ID]int64)
+	for it.NextRange() {
+		_, rng := it.Value()
+		counts[rng.ID] = rng.Count
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
 func (c *committedManager) Import(ctx context.Context, ns graveler.StorageNamespace, destination, source graveler.MetaRangeID, prefixes []graveler.Prefix, _ ...graveler.SetOptionsFunc) (graveler.MetaRangeID, error) {
 	destIt, err := c.metaRangeManager.NewMetaRangeIterator(ctx, ns, destination)
 	if err != nil {
@@ -10,6 +10,7 @@ import (
 	"github.com/treeverse/lakefs/pkg/graveler"
 	"github.com/treeverse/lakefs/pkg/graveler/committed"
 	"github.com/treeverse/lakefs/pkg/graveler/committed/mock"
+	"github.com/treeverse/lakefs/pkg/graveler/testutil"
 )
 
 func TestManager_WriteRange(t *testing.T) {
@@ -136,3 +137,33 @@ func TestManager_WriteMetaRange(t *testing.T) {
 		})
 	}
 }
+
+func TestManager_EstimateDiffSize(t *testing.T) {
+	const (
+		ns    = "some-ns"
+		left  = graveler.MetaRangeID("left")
+		right = graveler.MetaRangeID("right")
+	)
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	metarangeManager := mock.NewMockMetaRangeManager(ctrl)
+	rangeManager := mock.NewMockRangeManager(ctrl)
+
+	leftIt := testutil.NewFakeIterator().
+		AddRange(&committed.Range{ID: "shared", MinKey: committed.Key("a"), MaxKey: committed.Key("b"), Count: 10}).
+		AddRange(&committed.Range{ID: "left1", MinKey: committed.Key("c"), MaxKey: committed.Key("d"), Count: 5}).
+		AddRange(&committed.Range{ID: "left2", MinKey: committed.Key("e"), MaxKey: committed.Key("f"), Count: 7})
+	rightIt := testutil.NewFakeIterator().
+		AddRange(&committed.Range{ID: "shared", MinKey: committed.Key("a"), MaxKey: committed.Key("b"), Count: 10}).
+		AddRange(&committed.Range{ID: "right1", MinKey: committed.Key("c"), MaxKey: committed.Key("d"), Count: 6}).
+		AddRange(&committed.Range{ID: "right2", MinKey: committed.Key("e"), MaxKey: committed.Key("f"), Count: 8}).
+		AddRange(&committed.Range{ID: "right3", MinKey: committed.Key("g"), MaxKey: committed.Key("h"), Count: 3})
+	metarangeManager.EXPECT().NewMetaRangeIterator(ctx, graveler.StorageNamespace(ns), left).Return(leftIt, nil)
+	metarangeManager.EXPECT().NewMetaRangeIterator(ctx, graveler.StorageNamespace(ns), right).Return(rightIt, nil)
+
+	sut := committed.NewCommittedManager(metarangeManager, rangeManager, params)
+	size, err := sut.EstimateDiffSize(ctx, ns, left, right)
+	require.NoError(t, err)
+	// shared range is skipped, right side has more records in ranges that are not shared
+	require.Equal(t, 17, size)
+}
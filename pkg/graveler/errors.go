@@ -19,6 +19,7 @@ var (
 	ErrWriteToProtectedBranch       = wrapError(ErrProtectedBranch, "cannot write to protected branch")
 	ErrReadingFromStore             = errors.New("cannot read from store")
 	ErrCommitToProtectedBranch      = wrapError(ErrProtectedBranch, "cannot commit to protected branch")
+	ErrLinearHistoryRequired        = wrapError(ErrProtectedBranch, "linear history required, merge commits are not allowed")
 	ErrInvalidValue                 = fmt.Errorf("invalid value: %w", ErrInvalid)
 	ErrInvalidMergeBase             = fmt.Errorf("only 2 commits allowed in FindMergeBase: %w", ErrInvalidValue)
 	ErrNoCommitGeneration           = errors.New("no commit generation")
@@ -26,6 +27,7 @@ var (
 	ErrInvalidMergeStrategy         = wrapError(ErrUserVisible, "invalid merge strategy")
 	ErrInvalidRef                   = fmt.Errorf("ref: %w", ErrInvalidValue)
 	ErrInvalidCommitID              = fmt.Errorf("commit id: %w", ErrInvalidValue)
+	ErrInvalidCommitDate            = fmt.Errorf("commit date in the future: %w", ErrInvalidValue)
 	ErrInvalidBranchID              = fmt.Errorf("branch id: %w", ErrInvalidValue)
 	ErrInvalidTagID                 = fmt.Errorf("tag id: %w", ErrInvalidValue)
 	ErrInvalid                      = errors.New("validation error")
@@ -39,6 +41,7 @@ var (
 	ErrBranchNotFound               = fmt.Errorf("branch %w", ErrNotFound)
 	ErrSameBranch                   = fmt.Errorf("same branch %w", ErrInvalid)
 	ErrTagNotFound                  = fmt.Errorf("tag %w", ErrNotFound)
+	ErrRefNotFound                  = fmt.Errorf("reference %w", ErrNotFound)
 	ErrNoChanges                    = wrapError(ErrUserVisible, "no changes")
 	ErrConflictFound                = wrapError(ErrUserVisible, "conflict found")
 	ErrBranchExists                 = fmt.Errorf("branch already exists: %w", ErrNotUnique)
@@ -58,11 +61,14 @@ var (
 	ErrDeleteDefaultBranch          = wrapError(ErrUserVisible, "cannot delete repository default branch")
 	ErrCommitMetaRangeDirtyBranch   = wrapError(ErrUserVisible, "cannot use source MetaRange on a branch with uncommitted changes")
 	ErrTooManyTries                 = errors.New("too many tries")
+	ErrRateLimited                  = wrapError(ErrUserVisible, "rate limit exceeded")
 	ErrSkipValueUpdate              = errors.New("skip value update")
 	ErrImport                       = wrapError(ErrUserVisible, "import error")
 	ErrReadOnlyRepository           = wrapError(ErrUserVisible, "read-only repository")
+	ErrRepositoryInMaintenance      = wrapError(ErrUserVisible, "repository in maintenance")
 	ErrPullRequestNotFound          = fmt.Errorf("pull request %w", ErrNotFound)
 	ErrPullRequestExists            = fmt.Errorf("pull request already exists: %w", ErrNotUnique)
+	ErrHistoryTooLong               = fmt.Errorf("history too long: %w", ErrInvalidValue)
 )
 
 // wrappedError is an error for wrapping another error while ignoring its message.
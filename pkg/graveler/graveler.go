@@ -17,6 +17,7 @@ import (
 	"github.com/treeverse/lakefs/pkg/ident"
 	"github.com/treeverse/lakefs/pkg/kv"
 	"github.com/treeverse/lakefs/pkg/logging"
+	"golang.org/x/time/rate"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
@@ -29,6 +30,31 @@ var kvRetriesCounter = promauto.NewCounterVec(
 	[]string{"operation"},
 )
 
+var operationDuration = promauto.NewHistogramVec(
+	prometheus.HistogramOpts{
+		Name:    "graveler_operation_duration_seconds",
+		Help:    "Duration of Graveler operations.",
+		Buckets: []float64{0.01, 0.05, 0.1, 0.5, 1, 2.5, 5, 10, 30, 60, 120},
+	},
+	[]string{"operation"},
+)
+
+var operationFailures = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "graveler_operation_failures_total",
+		Help: "Number of failed Graveler operations.",
+	},
+	[]string{"operation"},
+)
+
+// observeOperation reports the duration of operation that started at start, and counts it as failed if err is set
+func observeOperation(operation string, start time.Time, err error) {
+	operationDuration.WithLabelValues(operation).Observe(time.Since(start).Seconds())
+	if err != nil {
+		operationFailures.WithLabelValues(operation).Inc()
+	}
+}
+
 //go:generate go run github.com/golang/mock/mockgen@v1.6.0 -source=graveler.go -destination=mock/graveler.go -package=mock
 
 const (
@@ -36,6 +62,7 @@ const (
 	BranchUpdateMaxTries    = 10
 
 	DeleteKeysMaxSize = 1000
+	SetKeysMaxSize    = 1000
 
 	// BranchWriteMaxTries is the number of times to repeat the set operation if the staging token changed
 	BranchWriteMaxTries = 3
@@ -43,6 +70,12 @@ const (
 	RepoMetadataUpdateMaxInterval    = 5 * time.Second
 	RepoMetadataUpdateMaxElapsedTime = 15 * time.Second
 	RepoMetadataUpdateRandomFactor   = 0.5
+
+	// CommitDateMaxFutureSkew is how far in the future an explicit commit date may be
+	CommitDateMaxFutureSkew = 24 * time.Hour
+
+	// IsAncestorMaxCommits is the number of commits read looking for an ancestor before giving up
+	IsAncestorMaxCommits = 100_000
 )
 
 // Basic Types
@@ -193,6 +226,14 @@ type SetOptions struct {
 	Force bool
 	// AllowEmpty set to true will allow committing an empty commit.
 	AllowEmpty bool
+	// ExpectedCommitID when set, the branch is updated only if it currently points to this commit.
+	ExpectedCommitID CommitID
+	// FastForward set to true will merge by moving the destination to the source commit, when the destination
+	// commit is an ancestor of the source commit, instead of adding a merge commit.
+	FastForward bool
+	// Squash set to true will merge by adding a commit whose only parent is the destination commit, so the source
+	// commits do not become part of the destination history.
+	Squash bool
 }
 
 type SetOptionsFunc func(opts *SetOptions)
@@ -223,6 +264,24 @@ func WithAllowEmpty(v bool) SetOptionsFunc {
 	}
 }
 
+func WithExpectedCommitID(v CommitID) SetOptionsFunc {
+	return func(opts *SetOptions) {
+		opts.ExpectedCommitID = v
+	}
+}
+
+func WithFastForward(v bool) SetOptionsFunc {
+	return func(opts *SetOptions) {
+		opts.FastForward = v
+	}
+}
+
+func WithSquash(v bool) SetOptionsFunc {
+	return func(opts *SetOptions) {
+		opts.Squash = v
+	}
+}
+
 // function/methods receiving the following basic types could assume they passed validation
 
 // StorageNamespace is the URI to the storage location
@@ -240,6 +299,10 @@ type Prefix string
 // Ref could be a commit ID, a branch name, a Tag
 type Ref string
 
+// HeadRef is a reserved ref that resolves to the repository default branch. New branches and tags cannot use it as a
+// name. A branch or tag named HEAD that was created before it was reserved takes precedence, so it can still be read.
+const HeadRef = "HEAD"
+
 // TagID represents a named tag pointing at a commit
 type TagID string
 
@@ -319,6 +382,9 @@ type Repository struct {
 	// ReadOnly indicates if the repository is a read-only repository. All write operations will be blocked for a
 	// read-only repository.
 	ReadOnly bool
+	// MaintenanceReason is set, to the reason given, while the repository is in maintenance mode. Write operations
+	// are blocked while it is set.
+	MaintenanceReason string
 }
 
 type RepositoryMetadata map[string]string
@@ -497,6 +563,9 @@ type CommitParams struct {
 	// SourceMetaRange - If exists, use it directly. Fail if branch has uncommitted changes
 	SourceMetaRange *MetaRangeID
 	AllowEmpty      bool
+	// Parents - If exists, use these commits as the parents of the commit instead of the branch head. The commit
+	// content is still the branch content. Used by tools that rewrite history.
+	Parents CommitParents
 }
 
 // CommitOverrides is intended to be used by operations
@@ -525,12 +594,19 @@ type KeyValueStore interface {
 	// GetByCommitID returns value from repository / commit by key and error if value does not exist
 	GetByCommitID(ctx context.Context, repository *RepositoryRecord, commitID CommitID, key Key) (*Value, error)
 
+	// GetByResolvedRef returns value from repository / resolved reference by key and error if value does not exist.
+	// Reading several keys from the same resolved reference reads them from the same commit and staging area.
+	GetByResolvedRef(ctx context.Context, repository *RepositoryRecord, reference *ResolvedRef, key Key, opts ...GetOptionsFunc) (*Value, error)
+
 	// GetRangeIDByKey returns rangeID from the commitID that contains the key
 	GetRangeIDByKey(ctx context.Context, repository *RepositoryRecord, commitID CommitID, key Key) (RangeID, error)
 
 	// Set stores value on repository / branch by key. nil value is a valid value for tombstone
 	Set(ctx context.Context, repository *RepositoryRecord, branchID BranchID, key Key, value Value, opts ...SetOptionsFunc) error
 
+	// SetBatch stores values on repository / branch by batch of records
+	SetBatch(ctx context.Context, repository *RepositoryRecord, branchID BranchID, records []ValueRecord, opts ...SetOptionsFunc) error
+
 	// Delete value from repository / branch by key
 	Delete(ctx context.Context, repository *RepositoryRecord, branchID BranchID, key Key, opts ...SetOptionsFunc) error
 
@@ -539,6 +615,10 @@ type KeyValueStore interface {
 
 	// List lists values on repository / ref
 	List(ctx context.Context, repository *RepositoryRecord, ref Ref, batchSize int) (ValueIterator, error)
+
+	// ListByResolvedRef lists values on repository / resolved reference. Listings of the same resolved reference
+	// read the same commit and staging area.
+	ListByResolvedRef(ctx context.Context, repository *RepositoryRecord, reference *ResolvedRef, batchSize int) (ValueIterator, error)
 }
 
 type VersionController interface {
@@ -563,6 +643,12 @@ type VersionController interface {
 	// SetRepositoryMetadata sets repository user metadata
 	SetRepositoryMetadata(ctx context.Context, repository *RepositoryRecord, updateFunc RepoMetadataUpdateFunc) error
 
+	// SetDefaultBranch sets the repository default branch to an existing branch
+	SetDefaultBranch(ctx context.Context, repository *RepositoryRecord, branchID BranchID) error
+
+	// SetRepositoryMaintenance sets the repository maintenance reason, an empty reason turns maintenance mode off
+	SetRepositoryMaintenance(ctx context.Context, repository *RepositoryRecord, reason string) error
+
 	// CreateBranch creates branch on repository pointing to ref
 	CreateBranch(ctx context.Context, repository *RepositoryRecord, branchID BranchID, ref Ref, opts ...SetOptionsFunc) (*Branch, error)
 
@@ -597,6 +683,11 @@ type VersionController interface {
 	//   ErrNothingToCommit in case there is no data in stage
 	Commit(ctx context.Context, repository *RepositoryRecord, branchID BranchID, commitParams CommitParams, opts ...SetOptionsFunc) (CommitID, error)
 
+	// CommitKeys commits only the staged changes of the given keys, leaving any other staged changes uncommitted.
+	// Keys without staged changes are ignored.
+	//   ErrNoChanges in case none of the keys has staged changes
+	CommitKeys(ctx context.Context, repository *RepositoryRecord, branchID BranchID, keys []Key, commitParams CommitParams, opts ...SetOptionsFunc) (CommitID, error)
+
 	// CreateCommitRecord creates a commit record in the repository.
 	CreateCommitRecord(ctx context.Context, repository *RepositoryRecord, commitID CommitID, commit Commit, opts ...SetOptionsFunc) error
 
@@ -639,6 +730,10 @@ type VersionController interface {
 	CherryPick(ctx context.Context, repository *RepositoryRecord, id BranchID, reference Ref, number *int, committer string, commitOverrides *CommitOverrides, opts ...SetOptionsFunc) (CommitID, error)
 
 	// Merge merges 'source' into 'destination' and returns the commit id for the created merge commit.
+	// With WithFastForward, when 'destination' is an ancestor of 'source' no merge commit is created, 'destination'
+	// is moved to the 'source' commit and its id is returned. With WithSquash, the merge commit has 'destination' as
+	// its only parent. When branch protection blocks BranchProtectionBlockedAction_MERGE_COMMIT on 'destination', a
+	// merge that would create a two-parent merge commit fails with ErrLinearHistoryRequired.
 	Merge(ctx context.Context, repository *RepositoryRecord, destination BranchID, source Ref, commitParams CommitParams, strategy string, opts ...SetOptionsFunc) (CommitID, error)
 
 	// Import creates a merge-commit in the destination branch using the source MetaRangeID, overriding any destination
@@ -652,13 +747,25 @@ type VersionController interface {
 	// This is similar to a two-dot (left..right) diff in git.
 	Diff(ctx context.Context, repository *RepositoryRecord, left, right Ref) (DiffIterator, error)
 
+	// EstimateDiffSize returns an approximate number of changes between the commits of 'left' and 'right' ref.
+	// The estimate is computed from range metadata only, without reading the entries, and is not an exact diff size.
+	EstimateDiffSize(ctx context.Context, repository *RepositoryRecord, left, right Ref) (int, error)
+
 	// Compare returns the difference between the commit where 'left' was last synced into 'right', and the most recent commit of `right`.
 	// This is similar to a three-dot (from...to) diff in git.
 	Compare(ctx context.Context, repository *RepositoryRecord, left, right Ref) (DiffIterator, error)
 
+	// CompareWithBase returns the changes made on 'right' relative to 'base', compared with 'left'. It is Compare
+	// with a merge base provided by the caller instead of the one found from the commits.
+	CompareWithBase(ctx context.Context, repository *RepositoryRecord, left, right, base Ref) (DiffIterator, error)
+
 	// FindMergeBase returns the 'from' commit, the 'to' commit and the merge base commit of 'from' and 'to' commits.
 	FindMergeBase(ctx context.Context, repository *RepositoryRecord, from Ref, to Ref) (*CommitRecord, *CommitRecord, *Commit, error)
 
+	// IsAncestor reports whether the commit of 'ancestor' is reachable from the commit of 'descendant'. A commit is its
+	// own ancestor. Fails with ErrHistoryTooLong after reading IsAncestorMaxCommits commits.
+	IsAncestor(ctx context.Context, repository *RepositoryRecord, ancestor Ref, descendant Ref) (bool, error)
+
 	// SetHooksHandler set handler for all graveler hooks
 	SetHooksHandler(handler HooksHandler)
 
@@ -854,6 +961,12 @@ type RefManager interface {
 	// SetRepositoryMetadata updates repository user metadata using the updateFunc
 	SetRepositoryMetadata(ctx context.Context, repository *RepositoryRecord, updateFunc RepoMetadataUpdateFunc) error
 
+	// SetRepositoryDefaultBranch updates the repository default branch
+	SetRepositoryDefaultBranch(ctx context.Context, repository *RepositoryRecord, branchID BranchID) error
+
+	// SetRepositoryMaintenance updates the repository maintenance reason
+	SetRepositoryMaintenance(ctx context.Context, repository *RepositoryRecord, reason string) error
+
 	// ParseRef returns parsed 'ref' information as RawRef
 	ParseRef(ref Ref) (RawRef, error)
 
@@ -965,6 +1078,11 @@ type CommittedManager interface {
 	// This is similar to a two-dot diff in git (left..right)
 	Diff(ctx context.Context, ns StorageNamespace, left, right MetaRangeID) (DiffIterator, error)
 
+	// EstimateDiffSize returns an approximate number of differences between two metaRanges.
+	// Only the range headers are read: ranges shared by both metaRanges are skipped and the records of the
+	// remaining ranges are counted, so the result is an estimate and not an exact diff size.
+	EstimateDiffSize(ctx context.Context, ns StorageNamespace, left, right MetaRangeID) (int, error)
+
 	// Compare returns the difference between 'source' and 'destination', relative to a merge base 'base'.
 	// This is similar to a three-dot diff in git.
 	Compare(ctx context.Context, ns StorageNamespace, destination, source, base MetaRangeID) (DiffIterator, error)
@@ -1098,6 +1216,7 @@ type Graveler struct {
 	logger              logging.Logger
 	BranchUpdateBackOff backoff.BackOff
 	deleteSensor        *DeleteSensor
+	commitRateLimiter   *BranchRateLimiter
 }
 
 func NewGraveler(committedManager CommittedManager, stagingManager StagingManager, refManager RefManager, gcManager GarbageCollectionManager, protectedBranchesManager ProtectedBranchesManager, deleteSensor *DeleteSensor) *Graveler {
@@ -1121,6 +1240,34 @@ func (g *Graveler) log(ctx context.Context) logging.Logger {
 	return g.logger.WithContext(ctx)
 }
 
+// SetCommitRateLimit throttles commits and merges on each branch to perBranch operations per second.
+// Operations exceeding the limit fail with ErrRateLimited. A zero limit disables throttling.
+func (g *Graveler) SetCommitRateLimit(perBranch rate.Limit) {
+	if perBranch <= 0 {
+		g.commitRateLimiter = nil
+		return
+	}
+	g.commitRateLimiter = NewBranchRateLimiter(perBranch)
+}
+
+func (g *Graveler) checkCommitRateLimit(repository *RepositoryRecord, branchID BranchID) error {
+	if g.commitRateLimiter != nil && !g.commitRateLimiter.Allow(repository.RepositoryID, branchID) {
+		return ErrRateLimited
+	}
+	return nil
+}
+
+// validateCommitDate rejects an explicit commit date more than CommitDateMaxFutureSkew in the future
+func validateCommitDate(date *int64) error {
+	if date == nil {
+		return nil
+	}
+	if time.Unix(*date, 0).After(time.Now().Add(CommitDateMaxFutureSkew)) {
+		return fmt.Errorf("%s: %w", time.Unix(*date, 0).UTC(), ErrInvalidCommitDate)
+	}
+	return nil
+}
+
 func (g *Graveler) GetRepository(ctx context.Context, repositoryID RepositoryID) (*RepositoryRecord, error) {
 	return g.RefManager.GetRepository(ctx, repositoryID)
 }
@@ -1169,6 +1316,17 @@ func (g *Graveler) SetRepositoryMetadata(ctx context.Context, repository *Reposi
 	return g.RefManager.SetRepositoryMetadata(ctx, repository, updateFunc)
 }
 
+func (g *Graveler) SetDefaultBranch(ctx context.Context, repository *RepositoryRecord, branchID BranchID) error {
+	if _, err := g.RefManager.GetBranch(ctx, repository, branchID); err != nil {
+		return err
+	}
+	return g.RefManager.SetRepositoryDefaultBranch(ctx, repository, branchID)
+}
+
+func (g *Graveler) SetRepositoryMaintenance(ctx context.Context, repository *RepositoryRecord, reason string) error {
+	return g.RefManager.SetRepositoryMaintenance(ctx, repository, reason)
+}
+
 func (g *Graveler) WriteRange(ctx context.Context, repository *RepositoryRecord, it ValueIterator, opts ...SetOptionsFunc) (*RangeInfo, error) {
 	options := NewSetOptions(opts)
 	if repository.ReadOnly && !options.Force {
@@ -1296,6 +1454,9 @@ func (g *Graveler) UpdateBranch(ctx context.Context, repository *RepositoryRecor
 	var tokensToDrop []StagingToken
 	var newBranch *Branch
 	err = g.RefManager.BranchUpdate(ctx, repository, branchID, func(currBranch *Branch) (*Branch, error) {
+		if options.ExpectedCommitID != "" && currBranch.CommitID != options.ExpectedCommitID {
+			return nil, fmt.Errorf("branch %s points to %s and not %s: %w", branchID, currBranch.CommitID, options.ExpectedCommitID, ErrPreconditionFailed)
+		}
 		// TODO(Guys) return error only on conflicts, currently returns error for any changes on staging
 		empty, err := g.isSealedEmpty(ctx, repository, currBranch)
 		if err != nil {
@@ -1662,8 +1823,14 @@ func (g *Graveler) Get(ctx context.Context, repository *RepositoryRecord, ref Re
 	if err != nil {
 		return nil, err
 	}
+	return g.GetByResolvedRef(ctx, repository, reference, key, opts...)
+}
 
-	var updatedValue *Value
+func (g *Graveler) GetByResolvedRef(ctx context.Context, repository *RepositoryRecord, reference *ResolvedRef, key Key, opts ...GetOptionsFunc) (*Value, error) {
+	var (
+		updatedValue *Value
+		err          error
+	)
 	if reference.StagingToken != "" {
 		// try to get from staging, if not found proceed to committed
 		updatedValue, err = g.getFromStagingArea(ctx, reference.Branch, key)
@@ -1776,6 +1943,44 @@ func (g *Graveler) Set(ctx context.Context, repository *RepositoryRecord, branch
 	return err
 }
 
+// SetBatch stores a batch of values on a branch. Records length is limited to SetKeysMaxSize. The branch is read
+// once for the whole batch, and the batch is written again if the staging token changes while writing. Staging has
+// no multi-key transactions, so a failed batch may be partially written; since setting a value is idempotent, the
+// caller can retry the same batch.
+func (g *Graveler) SetBatch(ctx context.Context, repository *RepositoryRecord, branchID BranchID, records []ValueRecord, opts ...SetOptionsFunc) error {
+	isProtected, err := g.protectedBranchesManager.IsBlocked(ctx, repository, branchID, BranchProtectionBlockedAction_STAGING_WRITE)
+	if err != nil {
+		return err
+	}
+	if isProtected {
+		return ErrWriteToProtectedBranch
+	}
+
+	options := NewSetOptions(opts)
+	if repository.ReadOnly && !options.Force {
+		return ErrReadOnlyRepository
+	}
+
+	if len(records) > SetKeysMaxSize {
+		return fmt.Errorf("records length (%d) passed the maximum allowed(%d): %w", len(records), SetKeysMaxSize, ErrInvalidValue)
+	}
+	for _, record := range records {
+		if record.Value == nil {
+			return fmt.Errorf("key %s: %w", record.Key, ErrInvalidValue)
+		}
+	}
+
+	log := g.log(ctx).WithField("operation", "set_keys")
+	return g.safeBranchWrite(ctx, log, repository, branchID, safeBranchWriteOptions{MaxTries: options.MaxTries}, func(branch *Branch) error {
+		for _, record := range records {
+			if err := g.StagingManager.Set(ctx, branch.StagingToken, record.Key, record.Value, false); err != nil {
+				return fmt.Errorf("key %s: %w", record.Key, err)
+			}
+		}
+		return nil
+	}, "set_keys")
+}
+
 // safeBranchWrite repeatedly attempts to perform stagingOperation, retrying
 // if the staging token changes during the write.  It never backs off.  It
 // returns the number of times it tried -- between 1 and options.MaxTries.
@@ -1967,6 +2172,10 @@ func (g *Graveler) List(ctx context.Context, repository *RepositoryRecord, ref R
 	if err != nil {
 		return nil, err
 	}
+	return g.ListByResolvedRef(ctx, repository, reference, batchSize)
+}
+
+func (g *Graveler) ListByResolvedRef(ctx context.Context, repository *RepositoryRecord, reference *ResolvedRef, batchSize int) (ValueIterator, error) {
 	var metaRangeID MetaRangeID
 	if reference.CompactedBaseMetaRangeID != "" {
 		metaRangeID = reference.CompactedBaseMetaRangeID
@@ -1995,6 +2204,15 @@ func (g *Graveler) List(ctx context.Context, repository *RepositoryRecord, ref R
 }
 
 func (g *Graveler) Commit(ctx context.Context, repository *RepositoryRecord, branchID BranchID, params CommitParams, opts ...SetOptionsFunc) (CommitID, error) {
+	start := time.Now()
+	commitID, err := g.commit(ctx, repository, branchID, nil, params, opts...)
+	observeOperation("commit", start, err)
+	return commitID, err
+}
+
+// commit commits the branch staged changes. When keys is not nil, only the staged changes of keys are committed and
+// the staging area is left as is - once committed, these changes are identical to the committed data.
+func (g *Graveler) commit(ctx context.Context, repository *RepositoryRecord, branchID BranchID, keys []Key, params CommitParams, opts ...SetOptionsFunc) (CommitID, error) {
 	var preRunID string
 	var commit Commit
 	var newCommitID CommitID
@@ -2013,24 +2231,48 @@ func (g *Graveler) Commit(ctx context.Context, repository *RepositoryRecord, bra
 	if repository.ReadOnly && !options.Force {
 		return "", ErrReadOnlyRepository
 	}
+	if keys != nil && params.SourceMetaRange != nil {
+		return "", fmt.Errorf("commit keys with source metarange: %w", ErrInvalid)
+	}
+	if err := validateCommitDate(params.Date); err != nil {
+		return "", err
+	}
+	// explicit parents must exist, the commit generation follows the latest of them
+	var parentsGeneration CommitGeneration
+	for _, parentID := range params.Parents {
+		parent, err := g.RefManager.GetCommit(ctx, repository, parentID)
+		if err != nil {
+			return "", fmt.Errorf("parent %s: %w", parentID, err)
+		}
+		if parent.Generation > parentsGeneration {
+			parentsGeneration = parent.Generation
+		}
+	}
+	// take the rate limit token only once the commit is known to be valid
+	if err := g.checkCommitRateLimit(repository, branchID); err != nil {
+		return "", err
+	}
 	storageNamespace = repository.StorageNamespace
 
-	err = g.RefManager.BranchUpdate(ctx, repository, branchID, func(branch *Branch) (*Branch, error) {
-		if params.SourceMetaRange != nil {
-			empty, err := g.isUncommittedEmpty(ctx, repository, branch)
-			if err != nil {
-				return nil, fmt.Errorf("checking empty branch: %w", err)
-			}
-			if !empty {
-				return nil, ErrCommitMetaRangeDirtyBranch
+	// committing keys leaves the staging area in place, no need to seal the staging token
+	if keys == nil {
+		err = g.RefManager.BranchUpdate(ctx, repository, branchID, func(branch *Branch) (*Branch, error) {
+			if params.SourceMetaRange != nil {
+				empty, err := g.isUncommittedEmpty(ctx, repository, branch)
+				if err != nil {
+					return nil, fmt.Errorf("checking empty branch: %w", err)
+				}
+				if !empty {
+					return nil, ErrCommitMetaRangeDirtyBranch
+				}
 			}
+			branch.SealedTokens = append([]StagingToken{branch.StagingToken}, branch.SealedTokens...)
+			branch.StagingToken = GenerateStagingToken(repository.RepositoryID, branchID)
+			return branch, nil
+		})
+		if err != nil {
+			return "", err
 		}
-		branch.SealedTokens = append([]StagingToken{branch.StagingToken}, branch.SealedTokens...)
-		branch.StagingToken = GenerateStagingToken(repository.RepositoryID, branchID)
-		return branch, nil
-	})
-	if err != nil {
-		return "", err
 	}
 
 	err = g.retryBranchUpdate(ctx, repository, branchID, func(branch *Branch) (*Branch, error) {
@@ -2043,7 +2285,9 @@ func (g *Graveler) Commit(ctx context.Context, repository *RepositoryRecord, bra
 		commit.Committer = params.Committer
 		commit.Message = params.Message
 		commit.Metadata = params.Metadata
-		if branch.CommitID != "" {
+		if len(params.Parents) > 0 {
+			commit.Parents = params.Parents
+		} else if branch.CommitID != "" {
 			commit.Parents = CommitParents{branch.CommitID}
 		}
 
@@ -2078,6 +2322,9 @@ func (g *Graveler) Commit(ctx context.Context, repository *RepositoryRecord, bra
 			parentGeneration = int(branchCommit.Generation)
 		}
 		commit.Generation = CommitGeneration(parentGeneration + 1)
+		if len(params.Parents) > 0 {
+			commit.Generation = parentsGeneration + 1
+		}
 		if params.SourceMetaRange != nil {
 			empty, err := g.isSealedEmpty(ctx, repository, branch)
 			if err != nil {
@@ -2088,7 +2335,7 @@ func (g *Graveler) Commit(ctx context.Context, repository *RepositoryRecord, bra
 			}
 			commit.MetaRangeID = *params.SourceMetaRange
 		} else {
-			changes, err := g.sealedTokensIterator(ctx, branch, 0)
+			changes, err := g.commitChangesIterator(ctx, branch, keys)
 			if err != nil {
 				return nil, err
 			}
@@ -2099,7 +2346,6 @@ func (g *Graveler) Commit(ctx context.Context, repository *RepositoryRecord, bra
 				return nil, fmt.Errorf("commit: %w", err)
 			}
 		}
-		sealedToDrop = branch.SealedTokens
 
 		// add commit
 		newCommitID, err = g.RefManager.AddCommit(ctx, repository, commit)
@@ -2108,7 +2354,10 @@ func (g *Graveler) Commit(ctx context.Context, repository *RepositoryRecord, bra
 		}
 
 		branch.CommitID = newCommitID
-		branch.SealedTokens = make([]StagingToken, 0)
+		if keys == nil {
+			sealedToDrop = branch.SealedTokens
+			branch.SealedTokens = make([]StagingToken, 0)
+		}
 		return branch, nil
 	}, "commit")
 	if err != nil {
@@ -2140,6 +2389,33 @@ func (g *Graveler) Commit(ctx context.Context, repository *RepositoryRecord, bra
 	return newCommitID, nil
 }
 
+// commitChangesIterator returns the changes to commit: the sealed tokens of the branch, or the latest staged change
+// of each of keys when keys is not nil.
+func (g *Graveler) commitChangesIterator(ctx context.Context, branch *Branch, keys []Key) (ValueIterator, error) {
+	if keys == nil {
+		return g.sealedTokensIterator(ctx, branch, 0)
+	}
+	changes, err := g.listStagingAreaWithoutCompaction(ctx, branch, 0)
+	if err != nil {
+		return nil, err
+	}
+	return NewFilterKeysIterator(changes, keys), nil
+}
+
+// CommitKeys commits the staged changes of keys on top of the branch head, using the same flow as Commit. Staged
+// changes of the committed keys are left in the staging area - once committed they are identical to the committed
+// data and no longer show as changes.
+func (g *Graveler) CommitKeys(ctx context.Context, repository *RepositoryRecord, branchID BranchID, keys []Key, params CommitParams, opts ...SetOptionsFunc) (CommitID, error) {
+	if keys == nil {
+		// nil keys commits the entire staging area
+		keys = []Key{}
+	}
+	start := time.Now()
+	commitID, err := g.commit(ctx, repository, branchID, keys, params, opts...)
+	observeOperation("commit_keys", start, err)
+	return commitID, err
+}
+
 func (g *Graveler) CreateCommitRecord(ctx context.Context, repository *RepositoryRecord, commitID CommitID, commit Commit, opts ...SetOptionsFunc) error {
 	options := NewSetOptions(opts)
 	if repository.ReadOnly && !options.Force {
@@ -2435,7 +2711,10 @@ func (g *Graveler) resetKey(ctx context.Context, repository *RepositoryRecord, b
 		// entry not committed and changed in staging area => override with tombstone
 		// If not committed and staging == tombstone => ignore
 	} else if !isCommitted && uncommittedValue != nil {
-		return g.deleteAndNotify(ctx, repository.RepositoryID, BranchRecord{branchID, branch}, key, false)
+		// write the tombstone on st, like the committed value above - branch staging token may already be sealed
+		stBranch := *branch
+		stBranch.StagingToken = st
+		return g.deleteAndNotify(ctx, repository.RepositoryID, BranchRecord{branchID, &stBranch}, key, false)
 	}
 
 	return nil
@@ -2527,11 +2806,7 @@ func (g *Graveler) ResetPrefix(ctx context.Context, repository *RepositoryRecord
 				break
 			}
 			wg.Go(func() error {
-				err = g.resetKey(ctx, repository, branchID, branch, value.Key, value.Value, newStagingToken)
-				if err != nil {
-					return err
-				}
-				return nil
+				return g.resetKey(ctx, repository, branchID, branch, value.Key, value.Value, newStagingToken)
 			})
 		}
 		err = wg.Wait().ErrorOrNil()
@@ -2758,15 +3033,26 @@ func applyCommitOverrides(commit *Commit, commitOverrides *CommitOverrides) {
 }
 
 func (g *Graveler) Merge(ctx context.Context, repository *RepositoryRecord, destination BranchID, source Ref, commitParams CommitParams, strategy string, opts ...SetOptionsFunc) (CommitID, error) {
+	start := time.Now()
+	commitID, err := g.merge(ctx, repository, destination, source, commitParams, strategy, opts...)
+	observeOperation("merge", start, err)
+	return commitID, err
+}
+
+func (g *Graveler) merge(ctx context.Context, repository *RepositoryRecord, destination BranchID, source Ref, commitParams CommitParams, strategy string, opts ...SetOptionsFunc) (CommitID, error) {
 	options := NewSetOptions(opts)
 	if repository.ReadOnly && !options.Force {
 		return "", ErrReadOnlyRepository
 	}
+	if options.FastForward && options.Squash {
+		return "", fmt.Errorf("fast-forward and squash merge: %w", ErrInvalidValue)
+	}
 
 	var (
-		preRunID string
-		commit   Commit
-		commitID CommitID
+		preRunID   string
+		commit     Commit
+		commitID   CommitID
+		tokenTaken bool
 	)
 
 	storageNamespace := repository.StorageNamespace
@@ -2822,28 +3108,66 @@ func (g *Graveler) Merge(ctx context.Context, repository *RepositoryRecord, dest
 			return nil, ErrInvalidMergeStrategy
 		}
 
-		metaRangeID, err := g.CommittedManager.Merge(ctx, storageNamespace, toCommit.MetaRangeID, fromCommit.MetaRangeID, baseCommit.MetaRangeID, mergeStrategy, opts...)
-		if err != nil {
-			if !errors.Is(err, ErrUserVisible) {
-				err = fmt.Errorf("merge in CommitManager: %w", err)
+		fastForward := false
+		if options.FastForward && fromCommit.CommitID != toCommit.CommitID {
+			fastForward, err = g.isAncestor(ctx, repository, toCommit, fromCommit)
+			if err != nil {
+				return nil, err
 			}
-			return nil, err
 		}
-		commit = NewCommit()
-		commit.Committer = commitParams.Committer
-		commit.Message = commitParams.Message
-		commit.MetaRangeID = metaRangeID
-		commit.Parents = []CommitID{toCommit.CommitID, fromCommit.CommitID}
-		if toCommit.Generation > fromCommit.Generation {
-			commit.Generation = toCommit.Generation + 1
-		} else {
-			commit.Generation = fromCommit.Generation + 1
+		if !fastForward && !options.Squash {
+			linearHistory, err := g.protectedBranchesManager.IsBlocked(ctx, repository, destination, BranchProtectionBlockedAction_MERGE_COMMIT)
+			if err != nil {
+				return nil, err
+			}
+			if linearHistory {
+				return nil, fmt.Errorf("%s: %w", destination, ErrLinearHistoryRequired)
+			}
 		}
-		metadata[MergeStrategyMetadataKey] = mergeStrategyString[mergeStrategy]
-		commit.Metadata = metadata
-		commitID, err = g.RefManager.AddCommit(ctx, repository, commit)
-		if err != nil {
-			return nil, fmt.Errorf("add commit: %w", err)
+		var metaRangeID MetaRangeID
+		if !fastForward {
+			metaRangeID, err = g.CommittedManager.Merge(ctx, storageNamespace, toCommit.MetaRangeID, fromCommit.MetaRangeID, baseCommit.MetaRangeID, mergeStrategy, opts...)
+			if err != nil {
+				if !errors.Is(err, ErrUserVisible) {
+					err = fmt.Errorf("merge in CommitManager: %w", err)
+				}
+				return nil, err
+			}
+		}
+		// take the rate limit token only once the merge is known to succeed, and once across branch update retries
+		if !tokenTaken {
+			if err := g.checkCommitRateLimit(repository, destination); err != nil {
+				return nil, err
+			}
+			tokenTaken = true
+		}
+		if fastForward {
+			// destination is an ancestor of source: move it to the source commit without a merge commit
+			commit = *fromCommit.Commit
+			commitID = fromCommit.CommitID
+		} else {
+			commit = NewCommit()
+			commit.Committer = commitParams.Committer
+			commit.Message = commitParams.Message
+			commit.MetaRangeID = metaRangeID
+			if options.Squash {
+				// the source commits do not become part of the destination history
+				commit.Parents = []CommitID{toCommit.CommitID}
+				commit.Generation = toCommit.Generation + 1
+			} else {
+				commit.Parents = []CommitID{toCommit.CommitID, fromCommit.CommitID}
+				if toCommit.Generation > fromCommit.Generation {
+					commit.Generation = toCommit.Generation + 1
+				} else {
+					commit.Generation = fromCommit.Generation + 1
+				}
+			}
+			metadata[MergeStrategyMetadataKey] = mergeStrategyString[mergeStrategy]
+			commit.Metadata = metadata
+			commitID, err = g.RefManager.AddCommit(ctx, repository, commit)
+			if err != nil {
+				return nil, fmt.Errorf("add commit: %w", err)
+			}
 		}
 		if !repository.ReadOnly {
 			preRunID = g.hooks.NewRunID()
@@ -3160,6 +3484,18 @@ func (g *Graveler) Diff(ctx context.Context, repository *RepositoryRecord, left,
 	return NewCombinedDiffIterator(compactedDiffIterator, leftValueIterator, stagingIterator), nil
 }
 
+func (g *Graveler) EstimateDiffSize(ctx context.Context, repository *RepositoryRecord, left, right Ref) (int, error) {
+	leftCommit, err := g.dereferenceCommit(ctx, repository, left)
+	if err != nil {
+		return 0, err
+	}
+	rightCommit, err := g.dereferenceCommit(ctx, repository, right)
+	if err != nil {
+		return 0, err
+	}
+	return g.CommittedManager.EstimateDiffSize(ctx, repository.StorageNamespace, leftCommit.MetaRangeID, rightCommit.MetaRangeID)
+}
+
 func (g *Graveler) FindMergeBase(ctx context.Context, repository *RepositoryRecord, from Ref, to Ref) (*CommitRecord, *CommitRecord, *Commit, error) {
 	fromCommit, err := g.dereferenceCommit(ctx, repository, from)
 	if err != nil {
@@ -3179,6 +3515,53 @@ func (g *Graveler) FindMergeBase(ctx context.Context, repository *RepositoryReco
 	return fromCommit, toCommit, baseCommit, nil
 }
 
+func (g *Graveler) IsAncestor(ctx context.Context, repository *RepositoryRecord, ancestor Ref, descendant Ref) (bool, error) {
+	ancestorCommit, err := g.dereferenceCommit(ctx, repository, ancestor)
+	if err != nil {
+		return false, fmt.Errorf("get commit by ref %s: %w", ancestor, err)
+	}
+	descendantCommit, err := g.dereferenceCommit(ctx, repository, descendant)
+	if err != nil {
+		return false, fmt.Errorf("get commit by ref %s: %w", descendant, err)
+	}
+	return g.isAncestor(ctx, repository, ancestorCommit, descendantCommit)
+}
+
+// isAncestor walks the history of descendant looking for ancestor. Commits are compared by ID. Parents of a commit
+// whose generation is not above that of ancestor cannot lead to it, so they are not read.
+func (g *Graveler) isAncestor(ctx context.Context, repository *RepositoryRecord, ancestor, descendant *CommitRecord) (bool, error) {
+	if ancestor.CommitID == descendant.CommitID {
+		return true, nil
+	}
+	visited := map[CommitID]struct{}{descendant.CommitID: {}}
+	queue := []*Commit{descendant.Commit}
+	for len(queue) > 0 {
+		commit := queue[0]
+		queue = queue[1:]
+		if ancestor.Generation > 0 && commit.Generation <= ancestor.Generation {
+			continue
+		}
+		for _, parentID := range commit.Parents {
+			if parentID == ancestor.CommitID {
+				return true, nil
+			}
+			if _, ok := visited[parentID]; ok {
+				continue
+			}
+			if len(visited) >= IsAncestorMaxCommits {
+				return false, fmt.Errorf("%s not reached in %d commits: %w", ancestor.CommitID, IsAncestorMaxCommits, ErrHistoryTooLong)
+			}
+			visited[parentID] = struct{}{}
+			parent, err := g.RefManager.GetCommit(ctx, repository, parentID)
+			if err != nil {
+				return false, err
+			}
+			queue = append(queue, parent)
+		}
+	}
+	return false, nil
+}
+
 func (g *Graveler) Compare(ctx context.Context, repository *RepositoryRecord, left, right Ref) (DiffIterator, error) {
 	fromCommit, toCommit, baseCommit, err := g.FindMergeBase(ctx, repository, right, left)
 	if err != nil {
@@ -3187,6 +3570,22 @@ func (g *Graveler) Compare(ctx context.Context, repository *RepositoryRecord, le
 	return g.CommittedManager.Compare(ctx, repository.StorageNamespace, toCommit.MetaRangeID, fromCommit.MetaRangeID, baseCommit.MetaRangeID)
 }
 
+func (g *Graveler) CompareWithBase(ctx context.Context, repository *RepositoryRecord, left, right, base Ref) (DiffIterator, error) {
+	leftCommit, err := g.dereferenceCommit(ctx, repository, left)
+	if err != nil {
+		return nil, fmt.Errorf("left: %w", err)
+	}
+	rightCommit, err := g.dereferenceCommit(ctx, repository, right)
+	if err != nil {
+		return nil, fmt.Errorf("right: %w", err)
+	}
+	baseCommit, err := g.dereferenceCommit(ctx, repository, base)
+	if err != nil {
+		return nil, fmt.Errorf("base: %w", err)
+	}
+	return g.CommittedManager.Compare(ctx, repository.StorageNamespace, leftCommit.MetaRangeID, rightCommit.MetaRangeID, baseCommit.MetaRangeID)
+}
+
 func (g *Graveler) SetHooksHandler(handler HooksHandler) {
 	if handler == nil {
 		g.hooks = &HooksNoOp{}
@@ -16,6 +16,7 @@ import (
 	"github.com/treeverse/lakefs/pkg/graveler/mock"
 	"github.com/treeverse/lakefs/pkg/graveler/testutil"
 	"github.com/treeverse/lakefs/pkg/kv"
+	"golang.org/x/time/rate"
 )
 
 type Hooks struct {
@@ -415,6 +416,75 @@ func TestGraveler_Set(t *testing.T) {
 	}
 }
 
+func TestGraveler_SetBatch(t *testing.T) {
+	ctx := context.Background()
+	records := []graveler.ValueRecord{
+		{Key: []byte("key1"), Value: &graveler.Value{Data: []byte("value1"), Identity: []byte("identity1")}},
+		{Key: []byte("key2"), Value: &graveler.Value{Data: []byte("value2"), Identity: []byte("identity2")}},
+	}
+
+	t.Run("set", func(t *testing.T) {
+		stagingMgr := &testutil.StagingFake{}
+		store := newGraveler(t, &testutil.CommittedFake{}, stagingMgr, &testutil.RefsFake{Branch: &graveler.Branch{}}, nil, testutil.NewProtectedBranchesManagerFake())
+		err := store.SetBatch(ctx, repository, "branch-1", records)
+		require.NoError(t, err)
+		require.Equal(t, &records[1], stagingMgr.LastSetValueRecord)
+	})
+
+	t.Run("nil value", func(t *testing.T) {
+		stagingMgr := &testutil.StagingFake{}
+		store := newGraveler(t, &testutil.CommittedFake{}, stagingMgr, &testutil.RefsFake{Branch: &graveler.Branch{}}, nil, testutil.NewProtectedBranchesManagerFake())
+		err := store.SetBatch(ctx, repository, "branch-1", []graveler.ValueRecord{records[0], {Key: []byte("key3")}})
+		require.ErrorIs(t, err, graveler.ErrInvalidValue)
+		require.Nil(t, stagingMgr.LastSetValueRecord)
+	})
+
+	t.Run("too many records", func(t *testing.T) {
+		stagingMgr := &testutil.StagingFake{}
+		store := newGraveler(t, &testutil.CommittedFake{}, stagingMgr, &testutil.RefsFake{Branch: &graveler.Branch{}}, nil, testutil.NewProtectedBranchesManagerFake())
+		err := store.SetBatch(ctx, repository, "branch-1", make([]graveler.ValueRecord, graveler.SetKeysMaxSize+1))
+		require.ErrorIs(t, err, graveler.ErrInvalidValue)
+	})
+
+	t.Run("staging failure", func(t *testing.T) {
+		stagingMgr := &testutil.StagingFake{SetErr: ErrGravelerUpdate}
+		store := newGraveler(t, &testutil.CommittedFake{}, stagingMgr, &testutil.RefsFake{Branch: &graveler.Branch{}}, nil, testutil.NewProtectedBranchesManagerFake())
+		err := store.SetBatch(ctx, repository, "branch-1", records)
+		require.ErrorIs(t, err, ErrGravelerUpdate)
+	})
+}
+
+func BenchmarkGraveler_SetBatch(b *testing.B) {
+	const numRecords = 100
+	ctx := context.Background()
+	records := make([]graveler.ValueRecord, numRecords)
+	for i := range records {
+		records[i] = graveler.ValueRecord{
+			Key:   []byte("key" + strconv.Itoa(i)),
+			Value: &graveler.Value{Data: []byte("value"), Identity: []byte("identity")},
+		}
+	}
+	store := graveler.NewGraveler(&testutil.CommittedFake{}, &testutil.StagingFake{}, &testutil.RefsFake{Branch: &graveler.Branch{}}, nil, testutil.NewProtectedBranchesManagerFake(), nil)
+
+	b.Run("set", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			for _, record := range records {
+				if err := store.Set(ctx, repository, "branch-1", record.Key, *record.Value); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+
+	b.Run("set_batch", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			if err := store.SetBatch(ctx, repository, "branch-1", records); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
 func TestGravelerSet_Advanced(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	ctx := context.Background()
@@ -1374,9 +1444,24 @@ func TestGraveler_UpdateBranch(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestGraveler_UpdateBranchExpectedCommitID(t *testing.T) {
+	newGravel := func() catalog.Store {
+		return newGraveler(t, &testutil.CommittedFake{ValueIterator: testutil.NewValueIteratorFake([]graveler.ValueRecord{})}, &testutil.StagingFake{ValueIterator: testutil.NewValueIteratorFake([]graveler.ValueRecord{})},
+			&testutil.RefsFake{Branch: &graveler.Branch{StagingToken: "st1", CommitID: "commit1"}, Commits: map[graveler.CommitID]*graveler.Commit{"commit1": {}}}, nil, nil)
+	}
+	ctx := context.Background()
+
+	_, err := newGravel().UpdateBranch(ctx, repository, "", "", graveler.WithExpectedCommitID("commit1"))
+	require.NoError(t, err)
+
+	_, err = newGravel().UpdateBranch(ctx, repository, "", "", graveler.WithExpectedCommitID("commit2"))
+	require.ErrorIs(t, err, graveler.ErrPreconditionFailed)
+}
+
 func TestGravelerCommit(t *testing.T) {
 	expectedCommitID := graveler.CommitID("expectedCommitId")
 	expectedRangeID := graveler.MetaRangeID("expectedRangeID")
+	futureDate := time.Now().Add(graveler.CommitDateMaxFutureSkew + time.Hour).Unix()
 	values := testutil.NewValueIteratorFake([]graveler.ValueRecord{{Key: nil, Value: nil}})
 	multipleValues := []graveler.ValueIterator{
 		testutil.NewValueIteratorFake([]graveler.ValueRecord{}),
@@ -1395,6 +1480,8 @@ func TestGravelerCommit(t *testing.T) {
 		message         string
 		metadata        graveler.Metadata
 		sourceMetarange *graveler.MetaRangeID
+		date            *int64
+		parents         graveler.CommitParents
 	}
 	tests := []struct {
 		name        string
@@ -1497,6 +1584,28 @@ func TestGravelerCommit(t *testing.T) {
 			values:      values,
 			expectedErr: graveler.ErrCommitMetaRangeDirtyBranch,
 		},
+		{
+			name: "commit date in the future",
+			fields: fields{
+				CommittedManager: &testutil.CommittedFake{MetaRangeID: expectedRangeID},
+				StagingManager:   &testutil.StagingFake{ValueIterator: values},
+				RefManager: &testutil.RefsFake{
+					CommitID: expectedCommitID,
+					Branch:   &graveler.Branch{CommitID: expectedCommitID},
+					Commits:  map[graveler.CommitID]*graveler.Commit{expectedCommitID: {MetaRangeID: expectedRangeID}},
+				},
+			},
+			args: args{
+				ctx:       nil,
+				branchID:  "branch",
+				committer: "committer",
+				message:   "a message",
+				metadata:  graveler.Metadata{},
+				date:      &futureDate,
+			},
+			values:      values,
+			expectedErr: graveler.ErrInvalidCommitDate,
+		},
 		{
 			name: "fail on apply",
 			fields: fields{
@@ -1608,6 +1717,54 @@ func TestGravelerCommit(t *testing.T) {
 			values:      graveler.NewCombinedIterator(multipleValues...),
 			expectedErr: nil,
 		},
+		{
+			name: "valid commit with parents",
+			fields: fields{
+				CommittedManager: &testutil.CommittedFake{MetaRangeID: expectedRangeID},
+				StagingManager:   &testutil.StagingFake{ValueIterator: values},
+				RefManager: &testutil.RefsFake{
+					CommitID: expectedCommitID,
+					Branch:   &graveler.Branch{CommitID: expectedCommitID},
+					Commits: map[graveler.CommitID]*graveler.Commit{
+						expectedCommitID: {MetaRangeID: expectedRangeID},
+						"parent1":        {MetaRangeID: expectedRangeID, Generation: 3},
+					},
+				},
+			},
+			args: args{
+				ctx:       nil,
+				branchID:  "branch",
+				committer: "committer",
+				message:   "a message",
+				metadata:  graveler.Metadata{},
+				parents:   graveler.CommitParents{"parent1"},
+			},
+			want:        expectedCommitID,
+			values:      values,
+			expectedErr: nil,
+		},
+		{
+			name: "commit with missing parent",
+			fields: fields{
+				CommittedManager: &testutil.CommittedFake{MetaRangeID: expectedRangeID},
+				StagingManager:   &testutil.StagingFake{ValueIterator: values},
+				RefManager: &testutil.RefsFake{
+					CommitID: expectedCommitID,
+					Branch:   &graveler.Branch{CommitID: expectedCommitID},
+					Commits:  map[graveler.CommitID]*graveler.Commit{expectedCommitID: {MetaRangeID: expectedRangeID}},
+				},
+			},
+			args: args{
+				ctx:       nil,
+				branchID:  "branch",
+				committer: "committer",
+				message:   "a message",
+				metadata:  graveler.Metadata{},
+				parents:   graveler.CommitParents{"missing"},
+			},
+			values:      values,
+			expectedErr: graveler.ErrCommitNotFound,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1623,6 +1780,8 @@ func TestGravelerCommit(t *testing.T) {
 				Message:         tt.args.message,
 				Metadata:        tt.args.metadata,
 				SourceMetaRange: tt.args.sourceMetarange,
+				Date:            tt.args.date,
+				Parents:         tt.args.parents,
 			})
 			if !errors.Is(err, tt.expectedErr) {
 				t.Fatalf("unexpected err got = %v, wanted = %v", err, tt.expectedErr)
@@ -1641,11 +1800,15 @@ func TestGravelerCommit(t *testing.T) {
 				t.Errorf("unexpected apply data %s", diff)
 			}
 
+			expectedParents := graveler.CommitParents{expectedCommitID}
+			if tt.args.parents != nil {
+				expectedParents = tt.args.parents
+			}
 			if diff := deep.Equal(tt.fields.RefManager.AddedCommit, testutil.AddedCommitData{
 				Committer:   tt.args.committer,
 				Message:     tt.args.message,
 				MetaRangeID: expectedRangeID,
-				Parents:     graveler.CommitParents{expectedCommitID},
+				Parents:     expectedParents,
 				Metadata:    graveler.Metadata{},
 			}); diff != nil {
 				t.Errorf("unexpected added commit %s", diff)
@@ -1661,6 +1824,270 @@ func TestGravelerCommit(t *testing.T) {
 	}
 }
 
+func TestGravelerCommitKeys(t *testing.T) {
+	const (
+		commitID    = graveler.CommitID("commitID")
+		metaRangeID = graveler.MetaRangeID("metaRangeID")
+	)
+	value := &graveler.Value{Identity: []byte("id1"), Data: []byte("data1")}
+	newFields := func() (*testutil.CommittedFake, *testutil.StagingFake, *testutil.RefsFake) {
+		committedManager := &testutil.CommittedFake{MetaRangeID: metaRangeID}
+		stagingManager := &testutil.StagingFake{
+			ValueIterator: testutil.NewValueIteratorFake([]graveler.ValueRecord{{Key: graveler.Key("key1"), Value: value}}),
+			Values:        map[string]map[string]*graveler.Value{"token": {"key1": value, "key2": value}},
+		}
+		refManager := &testutil.RefsFake{
+			CommitID: commitID,
+			Branch:   &graveler.Branch{CommitID: commitID, StagingToken: "token"},
+			Commits:  map[graveler.CommitID]*graveler.Commit{commitID: {MetaRangeID: metaRangeID}},
+		}
+		return committedManager, stagingManager, refManager
+	}
+	params := graveler.CommitParams{
+		Committer: "committer",
+		Message:   "a message",
+		Metadata:  graveler.Metadata{},
+	}
+
+	t.Run("commit staged key", func(t *testing.T) {
+		committedManager, stagingManager, refManager := newFields()
+		g := newGraveler(t, committedManager, stagingManager, refManager, nil, testutil.NewProtectedBranchesManagerFake())
+		got, err := g.CommitKeys(context.Background(), repository, "branch", []graveler.Key{graveler.Key("key1"), graveler.Key("missing")}, params)
+		require.NoError(t, err)
+		require.Equal(t, commitID, got)
+		var committedKeys []string
+		for committedManager.AppliedData.Values.Next() {
+			committedKeys = append(committedKeys, committedManager.AppliedData.Values.Value().Key.String())
+		}
+		require.Equal(t, []string{"key1"}, committedKeys)
+		require.Equal(t, testutil.AddedCommitData{
+			Committer:   params.Committer,
+			Message:     params.Message,
+			MetaRangeID: metaRangeID,
+			Parents:     graveler.CommitParents{commitID},
+			Metadata:    graveler.Metadata{},
+		}, refManager.AddedCommit)
+		require.False(t, stagingManager.DropCalled, "expected staging area to be left as is")
+	})
+
+	t.Run("explicit parents", func(t *testing.T) {
+		const parentID = graveler.CommitID("parentID")
+		committedManager, stagingManager, refManager := newFields()
+		refManager.Commits[parentID] = &graveler.Commit{MetaRangeID: metaRangeID}
+		g := newGraveler(t, committedManager, stagingManager, refManager, nil, testutil.NewProtectedBranchesManagerFake())
+		p := params
+		p.Parents = graveler.CommitParents{parentID}
+		_, err := g.CommitKeys(context.Background(), repository, "branch", []graveler.Key{graveler.Key("key1")}, p)
+		require.NoError(t, err)
+		require.Equal(t, graveler.CommitParents{parentID}, refManager.AddedCommit.Parents)
+	})
+
+	t.Run("protected branch", func(t *testing.T) {
+		committedManager, stagingManager, refManager := newFields()
+		g := newGraveler(t, committedManager, stagingManager, refManager, nil, testutil.NewProtectedBranchesManagerFake("branch"))
+		_, err := g.CommitKeys(context.Background(), repository, "branch", []graveler.Key{graveler.Key("key1")}, params)
+		require.ErrorIs(t, err, graveler.ErrCommitToProtectedBranch)
+	})
+
+	t.Run("source metarange", func(t *testing.T) {
+		committedManager, stagingManager, refManager := newFields()
+		g := newGraveler(t, committedManager, stagingManager, refManager, nil, testutil.NewProtectedBranchesManagerFake())
+		sourceMetaRange := metaRangeID
+		p := params
+		p.SourceMetaRange = &sourceMetaRange
+		_, err := g.CommitKeys(context.Background(), repository, "branch", []graveler.Key{graveler.Key("key1")}, p)
+		require.ErrorIs(t, err, graveler.ErrInvalid)
+	})
+}
+
+func TestGravelerCommitRateLimit(t *testing.T) {
+	const (
+		commitID    = graveler.CommitID("commitID")
+		metaRangeID = graveler.MetaRangeID("metaRangeID")
+	)
+	refManager := &testutil.RefsFake{
+		CommitID: commitID,
+		Branch:   &graveler.Branch{CommitID: commitID, StagingToken: "token"},
+		Commits:  map[graveler.CommitID]*graveler.Commit{commitID: {MetaRangeID: metaRangeID}},
+	}
+	g := graveler.NewGraveler(&testutil.CommittedFake{MetaRangeID: metaRangeID}, &testutil.StagingFake{ValueIterator: testutil.NewValueIteratorFake(nil)},
+		refManager, nil, testutil.NewProtectedBranchesManagerFake(), nil)
+	g.SetCommitRateLimit(rate.Limit(0.001))
+	params := graveler.CommitParams{Committer: "committer", Message: "a message", Metadata: graveler.Metadata{}}
+
+	// a rejected commit does not take the branch token
+	invalid := params
+	invalid.Parents = graveler.CommitParents{"missing"}
+	_, err := g.Commit(context.Background(), repository, "branch", invalid)
+	require.ErrorIs(t, err, graveler.ErrNotFound)
+
+	_, err = g.Commit(context.Background(), repository, "branch", params)
+	require.NoError(t, err)
+
+	_, err = g.Commit(context.Background(), repository, "branch", params)
+	require.ErrorIs(t, err, graveler.ErrRateLimited)
+}
+
+func TestGravelerMergeRateLimit(t *testing.T) {
+	const (
+		sourceCommitID      = graveler.CommitID("sourceCommitID")
+		destinationCommitID = graveler.CommitID("destinationCommitID")
+		metaRangeID         = graveler.MetaRangeID("metaRangeID")
+		destination         = graveler.BranchID("destination")
+	)
+	committedManager := &testutil.CommittedFake{MetaRangeID: metaRangeID}
+	refManager := &testutil.RefsFake{
+		CommitID: sourceCommitID,
+		Branch:   &graveler.Branch{CommitID: destinationCommitID, StagingToken: "token"},
+		Refs: map[graveler.Ref]*graveler.ResolvedRef{
+			graveler.Ref(destination): {
+				Type: graveler.ReferenceTypeBranch,
+				BranchRecord: graveler.BranchRecord{
+					BranchID: destination,
+					Branch:   &graveler.Branch{CommitID: destinationCommitID, StagingToken: "token"},
+				},
+			},
+		},
+		Commits: map[graveler.CommitID]*graveler.Commit{
+			sourceCommitID:      {MetaRangeID: metaRangeID},
+			destinationCommitID: {MetaRangeID: metaRangeID},
+		},
+	}
+	g := graveler.NewGraveler(committedManager, &testutil.StagingFake{ValueIterator: testutil.NewValueIteratorFake(nil)},
+		refManager, nil, testutil.NewProtectedBranchesManagerFake(), nil)
+	g.SetCommitRateLimit(rate.Limit(0.001))
+	ctx := context.Background()
+	params := graveler.CommitParams{Committer: "committer", Message: "a message", Metadata: graveler.Metadata{}}
+
+	// rejected merges do not take the branch token
+	_, err := g.Merge(ctx, repository, destination, sourceCommitID.Ref(), params, "unknown")
+	require.ErrorIs(t, err, graveler.ErrInvalidMergeStrategy)
+	committedManager.Err = graveler.ErrConflictFound
+	_, err = g.Merge(ctx, repository, destination, sourceCommitID.Ref(), params, "")
+	require.ErrorIs(t, err, graveler.ErrConflictFound)
+	committedManager.Err = nil
+
+	_, err = g.Merge(ctx, repository, destination, sourceCommitID.Ref(), params, "")
+	require.NoError(t, err)
+
+	_, err = g.Merge(ctx, repository, destination, sourceCommitID.Ref(), params, "")
+	require.ErrorIs(t, err, graveler.ErrRateLimited)
+}
+
+func TestGravelerMergeLinearHistory(t *testing.T) {
+	const (
+		sourceCommitID      = graveler.CommitID("sourceCommitID")
+		destinationCommitID = graveler.CommitID("destinationCommitID")
+		metaRangeID         = graveler.MetaRangeID("metaRangeID")
+		destination         = graveler.BranchID("destination")
+	)
+	refManager := &testutil.RefsFake{
+		CommitID: sourceCommitID,
+		Branch:   &graveler.Branch{CommitID: destinationCommitID, StagingToken: "token"},
+		Refs: map[graveler.Ref]*graveler.ResolvedRef{
+			graveler.Ref(destination): {
+				Type: graveler.ReferenceTypeBranch,
+				BranchRecord: graveler.BranchRecord{
+					BranchID: destination,
+					Branch:   &graveler.Branch{CommitID: destinationCommitID, StagingToken: "token"},
+				},
+			},
+		},
+		Commits: map[graveler.CommitID]*graveler.Commit{
+			sourceCommitID:      {MetaRangeID: metaRangeID},
+			destinationCommitID: {MetaRangeID: metaRangeID},
+		},
+	}
+	// the fake blocks all actions on destination, including merge commits
+	g := newGraveler(t, &testutil.CommittedFake{MetaRangeID: metaRangeID}, &testutil.StagingFake{ValueIterator: testutil.NewValueIteratorFake(nil)},
+		refManager, nil, testutil.NewProtectedBranchesManagerFake(string(destination)))
+	ctx := context.Background()
+	params := graveler.CommitParams{Committer: "committer", Message: "a message", Metadata: graveler.Metadata{}}
+
+	_, err := g.Merge(ctx, repository, destination, sourceCommitID.Ref(), params, "")
+	require.ErrorIs(t, err, graveler.ErrLinearHistoryRequired)
+	require.ErrorIs(t, err, graveler.ErrProtectedBranch)
+
+	_, err = g.Merge(ctx, repository, destination, sourceCommitID.Ref(), params, "", graveler.WithSquash(true))
+	require.NoError(t, err)
+}
+
+func TestGravelerIsAncestor(t *testing.T) {
+	// c1 <- c2 <- c3, with b1 branching from c1 and u an unrelated root commit
+	commits := map[graveler.CommitID]*graveler.Commit{
+		"c1": {Generation: 1},
+		"c2": {Generation: 2, Parents: graveler.CommitParents{"c1"}},
+		"c3": {Generation: 3, Parents: graveler.CommitParents{"c2"}},
+		"b1": {Generation: 2, Parents: graveler.CommitParents{"c1"}},
+		"u":  {Generation: 1},
+	}
+	refs := make(map[graveler.Ref]*graveler.ResolvedRef)
+	for commitID := range commits {
+		refs[commitID.Ref()] = &graveler.ResolvedRef{
+			Type:         graveler.ReferenceTypeCommit,
+			BranchRecord: graveler.BranchRecord{Branch: &graveler.Branch{CommitID: commitID}},
+		}
+	}
+	refs["missing"] = &graveler.ResolvedRef{
+		Type:         graveler.ReferenceTypeCommit,
+		BranchRecord: graveler.BranchRecord{Branch: &graveler.Branch{CommitID: "missing"}},
+	}
+	g := newGraveler(t, nil, nil, &testutil.RefsFake{Refs: refs, Commits: commits}, nil, nil)
+	ctx := context.Background()
+
+	tests := []struct {
+		name       string
+		ancestor   graveler.Ref
+		descendant graveler.Ref
+		want       bool
+		wantErr    error
+	}{
+		{name: "first parent history", ancestor: "c1", descendant: "c3", want: true},
+		{name: "descendant", ancestor: "c3", descendant: "c1", want: false},
+		{name: "same commit", ancestor: "c2", descendant: "c2", want: true},
+		{name: "sibling", ancestor: "b1", descendant: "c3", want: false},
+		{name: "unrelated", ancestor: "u", descendant: "c3", want: false},
+		{name: "missing", ancestor: "c1", descendant: "missing", wantErr: graveler.ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := g.IsAncestor(ctx, repository, tt.ancestor, tt.descendant)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("history too long", func(t *testing.T) {
+		// a chain longer than the walk limit, without generations so nothing is pruned
+		chain := map[graveler.CommitID]*graveler.Commit{"root": {}, "u": {}}
+		parent := graveler.CommitID("root")
+		for i := 0; i <= graveler.IsAncestorMaxCommits; i++ {
+			commitID := graveler.CommitID("c" + strconv.Itoa(i))
+			chain[commitID] = &graveler.Commit{Parents: graveler.CommitParents{parent}}
+			parent = commitID
+		}
+		nearCommitID := graveler.CommitID("c" + strconv.Itoa(graveler.IsAncestorMaxCommits-1))
+		chainRefs := map[graveler.Ref]*graveler.ResolvedRef{
+			"u":    {Type: graveler.ReferenceTypeCommit, BranchRecord: graveler.BranchRecord{Branch: &graveler.Branch{CommitID: "u"}}},
+			"near": {Type: graveler.ReferenceTypeCommit, BranchRecord: graveler.BranchRecord{Branch: &graveler.Branch{CommitID: nearCommitID}}},
+			"tip":  {Type: graveler.ReferenceTypeCommit, BranchRecord: graveler.BranchRecord{Branch: &graveler.Branch{CommitID: parent}}},
+		}
+		g := newGraveler(t, nil, nil, &testutil.RefsFake{Refs: chainRefs, Commits: chain}, nil, nil)
+
+		_, err := g.IsAncestor(ctx, repository, "u", "tip")
+		require.ErrorIs(t, err, graveler.ErrHistoryTooLong)
+
+		// a near ancestor is found without walking the whole chain
+		got, err := g.IsAncestor(ctx, repository, "near", "tip")
+		require.NoError(t, err)
+		require.True(t, got)
+	})
+}
+
 // TestGraveler_MergeInvalidRef test merge with invalid source reference in order
 func TestGraveler_MergeInvalidRef(t *testing.T) {
 	// prepare graveler
@@ -2128,6 +2555,27 @@ func TestGravelerDelete(t *testing.T) {
 	}
 }
 
+func TestGravelerDeleteBatch(t *testing.T) {
+	ctx := context.Background()
+	committedMgr := &testutil.CommittedFake{Err: graveler.ErrNotFound}
+	stagingMgr := &testutil.StagingFake{Err: graveler.ErrNotFound, SetErr: kv.ErrPredicateFailed}
+	refMgr := &testutil.RefsFake{
+		Branch:  &graveler.Branch{},
+		Commits: map[graveler.CommitID]*graveler.Commit{"": {}},
+	}
+	g := newGraveler(t, committedMgr, stagingMgr, refMgr, nil, testutil.NewProtectedBranchesManagerFake())
+
+	t.Run("batch ignores not found", func(t *testing.T) {
+		err := g.DeleteBatch(ctx, repository, "branch-1", []graveler.Key{[]byte("key1"), []byte("key2")})
+		require.NoError(t, err)
+	})
+
+	t.Run("single delete ignores not found", func(t *testing.T) {
+		err := g.Delete(ctx, repository, "branch-1", []byte("key1"))
+		require.NoError(t, err)
+	})
+}
+
 func TestGraveler_PreCommitHook(t *testing.T) {
 	// prepare graveler
 	const expectedRangeID = graveler.MetaRangeID("expectedRangeID")
@@ -355,6 +355,7 @@ func TestGravelerMerge(t *testing.T) {
 		test.RefManager.EXPECT().ResolveRawRef(ctx, repository, rawRefCommit1).Times(1).Return(&graveler.ResolvedRef{Type: graveler.ReferenceTypeCommit, BranchRecord: graveler.BranchRecord{Branch: &graveler.Branch{CommitID: commit1ID}}}, nil)
 		test.RefManager.EXPECT().GetCommit(ctx, repository, commit2ID).Times(1).Return(&commit2, nil)
 		test.RefManager.EXPECT().FindMergeBase(ctx, repository, commit2ID, commit1ID).Times(1).Return(&commit3, nil)
+		test.ProtectedBranchesManager.EXPECT().IsBlocked(ctx, repository, branch1ID, graveler.BranchProtectionBlockedAction_MERGE_COMMIT).Times(1).Return(false, nil)
 		test.CommittedManager.EXPECT().Merge(ctx, repository.StorageNamespace, mr1ID, mr2ID, mr3ID, graveler.MergeStrategyNone, []graveler.SetOptionsFunc{}).Times(1).Return(mr4ID, nil)
 		test.RefManager.EXPECT().AddCommit(ctx, repository, gomock.Any()).DoAndReturn(func(ctx context.Context, repository *graveler.RepositoryRecord, commit graveler.Commit) (graveler.CommitID, error) {
 			require.Equal(t, mr4ID, commit.MetaRangeID)
@@ -454,6 +455,7 @@ func TestGravelerMerge(t *testing.T) {
 		test.RefManager.EXPECT().ResolveRawRef(ctx, repository, rawRefCommit1).Times(1).Return(&graveler.ResolvedRef{Type: graveler.ReferenceTypeCommit, BranchRecord: graveler.BranchRecord{Branch: &graveler.Branch{CommitID: commit1ID}}}, nil)
 		test.RefManager.EXPECT().GetCommit(ctx, repository, commit2ID).Times(1).Return(&commit2, nil)
 		test.RefManager.EXPECT().FindMergeBase(ctx, repository, commit2ID, commit1ID).Times(1).Return(&commit3, nil)
+		test.ProtectedBranchesManager.EXPECT().IsBlocked(ctx, repository, branch1ID, graveler.BranchProtectionBlockedAction_MERGE_COMMIT).Times(1).Return(false, nil)
 		test.CommittedManager.EXPECT().Merge(ctx, repository.StorageNamespace, mr1ID, mr2ID, mr3ID, graveler.MergeStrategyNone, []graveler.SetOptionsFunc{}).Times(1).Return(mr4ID, nil)
 		test.RefManager.EXPECT().AddCommit(ctx, repository, gomock.Any()).DoAndReturn(func(ctx context.Context, repository *graveler.RepositoryRecord, commit graveler.Commit) (graveler.CommitID, error) {
 			require.Equal(t, mr4ID, commit.MetaRangeID)
@@ -484,6 +486,75 @@ func TestGravelerMerge(t *testing.T) {
 		require.Equal(t, commit4ID, graveler.CommitID(val.Ref()))
 	})
 
+	t.Run("merge fast-forward", func(t *testing.T) {
+		test := testutil.InitGravelerTest(t)
+		firstUpdateBranch(test)
+		emptyStagingTokenCombo(test, 2)
+		test.RefManager.EXPECT().GetCommit(ctx, repository, commit1ID).Times(3).Return(&commit1, nil)
+		test.CommittedManager.EXPECT().List(ctx, repository.StorageNamespace, mr1ID).Times(2).Return(testutils.NewFakeValueIterator(nil), nil)
+		test.RefManager.EXPECT().ParseRef(graveler.Ref(branch2ID)).Times(1).Return(rawRefCommit2, nil)
+		test.RefManager.EXPECT().ParseRef(graveler.Ref(branch1ID)).Times(1).Return(rawRefCommit1, nil)
+		test.RefManager.EXPECT().ResolveRawRef(ctx, repository, rawRefCommit2).Times(1).Return(&graveler.ResolvedRef{Type: graveler.ReferenceTypeCommit, BranchRecord: graveler.BranchRecord{Branch: &graveler.Branch{CommitID: commit2ID}}}, nil)
+		test.RefManager.EXPECT().ResolveRawRef(ctx, repository, rawRefCommit1).Times(1).Return(&graveler.ResolvedRef{Type: graveler.ReferenceTypeCommit, BranchRecord: graveler.BranchRecord{Branch: &graveler.Branch{CommitID: commit1ID}}}, nil)
+		// the source commit is a child of the destination commit
+		sourceCommit := graveler.Commit{MetaRangeID: mr2ID, Parents: []graveler.CommitID{commit1ID}}
+		test.RefManager.EXPECT().GetCommit(ctx, repository, commit2ID).Times(1).Return(&sourceCommit, nil)
+		test.RefManager.EXPECT().FindMergeBase(ctx, repository, commit2ID, commit1ID).Times(1).Return(&commit1, nil)
+		test.RefManager.EXPECT().BranchUpdate(ctx, repository, branch1ID, gomock.Any()).
+			Do(func(_ context.Context, _ *graveler.RepositoryRecord, _ graveler.BranchID, f graveler.BranchUpdateFunc) error {
+				branchTest := &graveler.Branch{StagingToken: stagingToken4, CommitID: commit1ID, SealedTokens: []graveler.StagingToken{stagingToken1, stagingToken2, stagingToken3}}
+				updatedBranch, err := f(branchTest)
+				require.NoError(t, err)
+				require.Equal(t, []graveler.StagingToken{}, updatedBranch.SealedTokens)
+				require.Equal(t, commit2ID, updatedBranch.CommitID)
+				return nil
+			}).Times(1)
+		test.StagingManager.EXPECT().DropAsync(ctx, stagingToken1).Times(1)
+		test.StagingManager.EXPECT().DropAsync(ctx, stagingToken2).Times(1)
+		test.StagingManager.EXPECT().DropAsync(ctx, stagingToken3).Times(1)
+
+		val, err := test.Sut.Merge(ctx, repository, branch1ID, graveler.Ref(branch2ID), graveler.CommitParams{Metadata: graveler.Metadata{}}, "", graveler.WithFastForward(true))
+
+		require.NoError(t, err)
+		require.Equal(t, commit2ID, val)
+	})
+
+	t.Run("merge squash", func(t *testing.T) {
+		test := testutil.InitGravelerTest(t)
+		firstUpdateBranch(test)
+		emptyStagingTokenCombo(test, 2)
+		test.RefManager.EXPECT().GetCommit(ctx, repository, commit1ID).Times(3).Return(&commit1, nil)
+		test.CommittedManager.EXPECT().List(ctx, repository.StorageNamespace, mr1ID).Times(2).Return(testutils.NewFakeValueIterator(nil), nil)
+		test.RefManager.EXPECT().ParseRef(graveler.Ref(branch2ID)).Times(1).Return(rawRefCommit2, nil)
+		test.RefManager.EXPECT().ParseRef(graveler.Ref(branch1ID)).Times(1).Return(rawRefCommit1, nil)
+		test.RefManager.EXPECT().ResolveRawRef(ctx, repository, rawRefCommit2).Times(1).Return(&graveler.ResolvedRef{Type: graveler.ReferenceTypeCommit, BranchRecord: graveler.BranchRecord{Branch: &graveler.Branch{CommitID: commit2ID}}}, nil)
+		test.RefManager.EXPECT().ResolveRawRef(ctx, repository, rawRefCommit1).Times(1).Return(&graveler.ResolvedRef{Type: graveler.ReferenceTypeCommit, BranchRecord: graveler.BranchRecord{Branch: &graveler.Branch{CommitID: commit1ID}}}, nil)
+		test.RefManager.EXPECT().GetCommit(ctx, repository, commit2ID).Times(1).Return(&commit2, nil)
+		test.RefManager.EXPECT().FindMergeBase(ctx, repository, commit2ID, commit1ID).Times(1).Return(&commit3, nil)
+		test.CommittedManager.EXPECT().Merge(ctx, repository.StorageNamespace, mr1ID, mr2ID, mr3ID, graveler.MergeStrategyNone, gomock.Any()).Times(1).Return(mr4ID, nil)
+		test.RefManager.EXPECT().AddCommit(ctx, repository, gomock.Any()).DoAndReturn(func(ctx context.Context, repository *graveler.RepositoryRecord, commit graveler.Commit) (graveler.CommitID, error) {
+			require.Equal(t, mr4ID, commit.MetaRangeID)
+			require.Equal(t, []graveler.CommitID{commit1ID}, commit.Parents)
+			return commit4ID, nil
+		}).Times(1)
+		test.RefManager.EXPECT().BranchUpdate(ctx, repository, branch1ID, gomock.Any()).
+			Do(func(_ context.Context, _ *graveler.RepositoryRecord, _ graveler.BranchID, f graveler.BranchUpdateFunc) error {
+				branchTest := &graveler.Branch{StagingToken: stagingToken4, CommitID: commit1ID, SealedTokens: []graveler.StagingToken{stagingToken1, stagingToken2, stagingToken3}}
+				updatedBranch, err := f(branchTest)
+				require.NoError(t, err)
+				require.Equal(t, commit4ID, updatedBranch.CommitID)
+				return nil
+			}).Times(1)
+		test.StagingManager.EXPECT().DropAsync(ctx, stagingToken1).Times(1)
+		test.StagingManager.EXPECT().DropAsync(ctx, stagingToken2).Times(1)
+		test.StagingManager.EXPECT().DropAsync(ctx, stagingToken3).Times(1)
+
+		val, err := test.Sut.Merge(ctx, repository, branch1ID, graveler.Ref(branch2ID), graveler.CommitParams{Metadata: graveler.Metadata{}}, "", graveler.WithSquash(true))
+
+		require.NoError(t, err)
+		require.Equal(t, commit4ID, val)
+	})
+
 	t.Run("merge fails due to BranchUpdate retries exhaustion", func(t *testing.T) {
 		test := testutil.InitGravelerTest(t)
 		firstUpdateBranch(test)
@@ -976,6 +1047,24 @@ func TestGravelerCreateCommitRecord_v2(t *testing.T) {
 	})
 }
 
+func TestGravelerSetDefaultBranch(t *testing.T) {
+	ctx := context.Background()
+	t.Run("existing branch", func(t *testing.T) {
+		test := testutil.InitGravelerTest(t)
+		test.RefManager.EXPECT().GetBranch(ctx, repository, graveler.BranchID("main")).Return(&graveler.Branch{CommitID: "c1"}, nil)
+		test.RefManager.EXPECT().SetRepositoryDefaultBranch(ctx, repository, graveler.BranchID("main")).Return(nil)
+		err := test.Sut.SetDefaultBranch(ctx, repository, "main")
+		require.NoError(t, err)
+	})
+
+	t.Run("missing branch", func(t *testing.T) {
+		test := testutil.InitGravelerTest(t)
+		test.RefManager.EXPECT().GetBranch(ctx, repository, graveler.BranchID("main")).Return(nil, graveler.ErrBranchNotFound)
+		err := test.Sut.SetDefaultBranch(ctx, repository, "main")
+		require.ErrorIs(t, err, graveler.ErrBranchNotFound)
+	})
+}
+
 func TestGravelerImport(t *testing.T) {
 	ctx := context.Background()
 
@@ -109,6 +109,26 @@ func (mr *MockKeyValueStoreMockRecorder) GetByCommitID(ctx, repository, commitID
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCommitID", reflect.TypeOf((*MockKeyValueStore)(nil).GetByCommitID), ctx, repository, commitID, key)
 }
 
+// GetByResolvedRef mocks base method.
+func (m *MockKeyValueStore) GetByResolvedRef(ctx context.Context, repository *graveler.RepositoryRecord, reference *graveler.ResolvedRef, key graveler.Key, opts ...graveler.GetOptionsFunc) (*graveler.Value, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, repository, reference, key}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetByResolvedRef", varargs...)
+	ret0, _ := ret[0].(*graveler.Value)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByResolvedRef indicates an expected call of GetByResolvedRef.
+func (mr *MockKeyValueStoreMockRecorder) GetByResolvedRef(ctx, repository, reference, key interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, repository, reference, key}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByResolvedRef", reflect.TypeOf((*MockKeyValueStore)(nil).GetByResolvedRef), varargs...)
+}
+
 // GetRangeIDByKey mocks base method.
 func (m *MockKeyValueStore) GetRangeIDByKey(ctx context.Context, repository *graveler.RepositoryRecord, commitID graveler.CommitID, key graveler.Key) (graveler.RangeID, error) {
 	m.ctrl.T.Helper()
@@ -139,6 +159,21 @@ func (mr *MockKeyValueStoreMockRecorder) List(ctx, repository, ref, batchSize in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockKeyValueStore)(nil).List), ctx, repository, ref, batchSize)
 }
 
+// ListByResolvedRef mocks base method.
+func (m *MockKeyValueStore) ListByResolvedRef(ctx context.Context, repository *graveler.RepositoryRecord, reference *graveler.ResolvedRef, batchSize int) (graveler.ValueIterator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByResolvedRef", ctx, repository, reference, batchSize)
+	ret0, _ := ret[0].(graveler.ValueIterator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByResolvedRef indicates an expected call of ListByResolvedRef.
+func (mr *MockKeyValueStoreMockRecorder) ListByResolvedRef(ctx, repository, reference, batchSize interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByResolvedRef", reflect.TypeOf((*MockKeyValueStore)(nil).ListByResolvedRef), ctx, repository, reference, batchSize)
+}
+
 // Set mocks base method.
 func (m *MockKeyValueStore) Set(ctx context.Context, repository *graveler.RepositoryRecord, branchID graveler.BranchID, key graveler.Key, value graveler.Value, opts ...graveler.SetOptionsFunc) error {
 	m.ctrl.T.Helper()
@@ -158,6 +193,25 @@ func (mr *MockKeyValueStoreMockRecorder) Set(ctx, repository, branchID, key, val
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockKeyValueStore)(nil).Set), varargs...)
 }
 
+// SetBatch mocks base method.
+func (m *MockKeyValueStore) SetBatch(ctx context.Context, repository *graveler.RepositoryRecord, branchID graveler.BranchID, records []graveler.ValueRecord, opts ...graveler.SetOptionsFunc) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, repository, branchID, records}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SetBatch", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetBatch indicates an expected call of SetBatch.
+func (mr *MockKeyValueStoreMockRecorder) SetBatch(ctx, repository, branchID, records interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, repository, branchID, records}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBatch", reflect.TypeOf((*MockKeyValueStore)(nil).SetBatch), varargs...)
+}
+
 // MockVersionController is a mock of VersionController interface.
 type MockVersionController struct {
 	ctrl     *gomock.Controller
@@ -241,6 +295,26 @@ func (mr *MockVersionControllerMockRecorder) Commit(ctx, repository, branchID, c
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Commit", reflect.TypeOf((*MockVersionController)(nil).Commit), varargs...)
 }
 
+// CommitKeys mocks base method.
+func (m *MockVersionController) CommitKeys(ctx context.Context, repository *graveler.RepositoryRecord, branchID graveler.BranchID, keys []graveler.Key, commitParams graveler.CommitParams, opts ...graveler.SetOptionsFunc) (graveler.CommitID, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, repository, branchID, keys, commitParams}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CommitKeys", varargs...)
+	ret0, _ := ret[0].(graveler.CommitID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CommitKeys indicates an expected call of CommitKeys.
+func (mr *MockVersionControllerMockRecorder) CommitKeys(ctx, repository, branchID, keys, commitParams interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, repository, branchID, keys, commitParams}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommitKeys", reflect.TypeOf((*MockVersionController)(nil).CommitKeys), varargs...)
+}
+
 // Compare mocks base method.
 func (m *MockVersionController) Compare(ctx context.Context, repository *graveler.RepositoryRecord, left, right graveler.Ref) (graveler.DiffIterator, error) {
 	m.ctrl.T.Helper()
@@ -256,6 +330,21 @@ func (mr *MockVersionControllerMockRecorder) Compare(ctx, repository, left, righ
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Compare", reflect.TypeOf((*MockVersionController)(nil).Compare), ctx, repository, left, right)
 }
 
+// CompareWithBase mocks base method.
+func (m *MockVersionController) CompareWithBase(ctx context.Context, repository *graveler.RepositoryRecord, left, right, base graveler.Ref) (graveler.DiffIterator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompareWithBase", ctx, repository, left, right, base)
+	ret0, _ := ret[0].(graveler.DiffIterator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompareWithBase indicates an expected call of CompareWithBase.
+func (mr *MockVersionControllerMockRecorder) CompareWithBase(ctx, repository, left, right, base interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompareWithBase", reflect.TypeOf((*MockVersionController)(nil).CompareWithBase), ctx, repository, left, right, base)
+}
+
 // CreateBareRepository mocks base method.
 func (m *MockVersionController) CreateBareRepository(ctx context.Context, repositoryID graveler.RepositoryID, storageNamespace graveler.StorageNamespace, defaultBranchID graveler.BranchID, readOnly bool) (*graveler.RepositoryRecord, error) {
 	m.ctrl.T.Helper()
@@ -460,6 +549,21 @@ func (mr *MockVersionControllerMockRecorder) DiffUncommitted(ctx, repository, br
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiffUncommitted", reflect.TypeOf((*MockVersionController)(nil).DiffUncommitted), ctx, repository, branchID)
 }
 
+// EstimateDiffSize mocks base method.
+func (m *MockVersionController) EstimateDiffSize(ctx context.Context, repository *graveler.RepositoryRecord, left, right graveler.Ref) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EstimateDiffSize", ctx, repository, left, right)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EstimateDiffSize indicates an expected call of EstimateDiffSize.
+func (mr *MockVersionControllerMockRecorder) EstimateDiffSize(ctx, repository, left, right interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EstimateDiffSize", reflect.TypeOf((*MockVersionController)(nil).EstimateDiffSize), ctx, repository, left, right)
+}
+
 // FindMergeBase mocks base method.
 func (m *MockVersionController) FindMergeBase(ctx context.Context, repository *graveler.RepositoryRecord, from, to graveler.Ref) (*graveler.CommitRecord, *graveler.CommitRecord, *graveler.Commit, error) {
 	m.ctrl.T.Helper()
@@ -647,6 +751,21 @@ func (mr *MockVersionControllerMockRecorder) Import(ctx, repository, destination
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockVersionController)(nil).Import), varargs...)
 }
 
+// IsAncestor mocks base method.
+func (m *MockVersionController) IsAncestor(ctx context.Context, repository *graveler.RepositoryRecord, ancestor, descendant graveler.Ref) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsAncestor", ctx, repository, ancestor, descendant)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsAncestor indicates an expected call of IsAncestor.
+func (mr *MockVersionControllerMockRecorder) IsAncestor(ctx, repository, ancestor, descendant interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsAncestor", reflect.TypeOf((*MockVersionController)(nil).IsAncestor), ctx, repository, ancestor, descendant)
+}
+
 // ListBranches mocks base method.
 func (m *MockVersionController) ListBranches(ctx context.Context, repository *graveler.RepositoryRecord) (graveler.BranchIterator, error) {
 	m.ctrl.T.Helper()
@@ -882,6 +1001,20 @@ func (mr *MockVersionControllerMockRecorder) SetBranchProtectionRules(ctx, repos
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBranchProtectionRules", reflect.TypeOf((*MockVersionController)(nil).SetBranchProtectionRules), ctx, repository, rules, lastKnownChecksum)
 }
 
+// SetDefaultBranch mocks base method.
+func (m *MockVersionController) SetDefaultBranch(ctx context.Context, repository *graveler.RepositoryRecord, branchID graveler.BranchID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetDefaultBranch", ctx, repository, branchID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetDefaultBranch indicates an expected call of SetDefaultBranch.
+func (mr *MockVersionControllerMockRecorder) SetDefaultBranch(ctx, repository, branchID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDefaultBranch", reflect.TypeOf((*MockVersionController)(nil).SetDefaultBranch), ctx, repository, branchID)
+}
+
 // SetGarbageCollectionRules mocks base method.
 func (m *MockVersionController) SetGarbageCollectionRules(ctx context.Context, repository *graveler.RepositoryRecord, rules *graveler.GarbageCollectionRules) error {
 	m.ctrl.T.Helper()
@@ -908,6 +1041,20 @@ func (mr *MockVersionControllerMockRecorder) SetHooksHandler(handler interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHooksHandler", reflect.TypeOf((*MockVersionController)(nil).SetHooksHandler), handler)
 }
 
+// SetRepositoryMaintenance mocks base method.
+func (m *MockVersionController) SetRepositoryMaintenance(ctx context.Context, repository *graveler.RepositoryRecord, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRepositoryMaintenance", ctx, repository, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRepositoryMaintenance indicates an expected call of SetRepositoryMaintenance.
+func (mr *MockVersionControllerMockRecorder) SetRepositoryMaintenance(ctx, repository, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRepositoryMaintenance", reflect.TypeOf((*MockVersionController)(nil).SetRepositoryMaintenance), ctx, repository, reason)
+}
+
 // SetRepositoryMetadata mocks base method.
 func (m *MockVersionController) SetRepositoryMetadata(ctx context.Context, repository *graveler.RepositoryRecord, updateFunc graveler.RepoMetadataUpdateFunc) error {
 	m.ctrl.T.Helper()
@@ -2497,6 +2644,34 @@ func (mr *MockRefManagerMockRecorder) SetBranch(ctx, repository, branchID, branc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBranch", reflect.TypeOf((*MockRefManager)(nil).SetBranch), ctx, repository, branchID, branch)
 }
 
+// SetRepositoryDefaultBranch mocks base method.
+func (m *MockRefManager) SetRepositoryDefaultBranch(ctx context.Context, repository *graveler.RepositoryRecord, branchID graveler.BranchID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRepositoryDefaultBranch", ctx, repository, branchID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRepositoryDefaultBranch indicates an expected call of SetRepositoryDefaultBranch.
+func (mr *MockRefManagerMockRecorder) SetRepositoryDefaultBranch(ctx, repository, branchID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRepositoryDefaultBranch", reflect.TypeOf((*MockRefManager)(nil).SetRepositoryDefaultBranch), ctx, repository, branchID)
+}
+
+// SetRepositoryMaintenance mocks base method.
+func (m *MockRefManager) SetRepositoryMaintenance(ctx context.Context, repository *graveler.RepositoryRecord, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRepositoryMaintenance", ctx, repository, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRepositoryMaintenance indicates an expected call of SetRepositoryMaintenance.
+func (mr *MockRefManagerMockRecorder) SetRepositoryMaintenance(ctx, repository, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRepositoryMaintenance", reflect.TypeOf((*MockRefManager)(nil).SetRepositoryMaintenance), ctx, repository, reason)
+}
+
 // SetRepositoryMetadata mocks base method.
 func (m *MockRefManager) SetRepositoryMetadata(ctx context.Context, repository *graveler.RepositoryRecord, updateFunc graveler.RepoMetadataUpdateFunc) error {
 	m.ctrl.T.Helper()
@@ -2599,6 +2774,21 @@ func (mr *MockCommittedManagerMockRecorder) Diff(ctx, ns, left, right interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Diff", reflect.TypeOf((*MockCommittedManager)(nil).Diff), ctx, ns, left, right)
 }
 
+// EstimateDiffSize mocks base method.
+func (m *MockCommittedManager) EstimateDiffSize(ctx context.Context, ns graveler.StorageNamespace, left, right graveler.MetaRangeID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EstimateDiffSize", ctx, ns, left, right)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EstimateDiffSize indicates an expected call of EstimateDiffSize.
+func (mr *MockCommittedManagerMockRecorder) EstimateDiffSize(ctx, ns, left, right interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EstimateDiffSize", reflect.TypeOf((*MockCommittedManager)(nil).EstimateDiffSize), ctx, ns, left, right)
+}
+
 // Exists mocks base method.
 func (m *MockCommittedManager) Exists(ctx context.Context, ns graveler.StorageNamespace, id graveler.MetaRangeID) (bool, error) {
 	m.ctrl.T.Helper()
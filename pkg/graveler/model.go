@@ -118,25 +118,27 @@ func RepoFromProto(pb *RepositoryData) *RepositoryRecord {
 	return &RepositoryRecord{
 		RepositoryID: RepositoryID(pb.Id),
 		Repository: &Repository{
-			StorageNamespace: StorageNamespace(pb.StorageNamespace),
-			DefaultBranchID:  BranchID(pb.DefaultBranchId),
-			CreationDate:     pb.CreationDate.AsTime(),
-			InstanceUID:      pb.InstanceUid,
-			State:            pb.State,
-			ReadOnly:         pb.ReadOnly,
+			StorageNamespace:  StorageNamespace(pb.StorageNamespace),
+			DefaultBranchID:   BranchID(pb.DefaultBranchId),
+			CreationDate:      pb.CreationDate.AsTime(),
+			InstanceUID:       pb.InstanceUid,
+			State:             pb.State,
+			ReadOnly:          pb.ReadOnly,
+			MaintenanceReason: pb.MaintenanceReason,
 		},
 	}
 }
 
 func ProtoFromRepo(repo *RepositoryRecord) *RepositoryData {
 	return &RepositoryData{
-		Id:               repo.RepositoryID.String(),
-		StorageNamespace: repo.Repository.StorageNamespace.String(),
-		DefaultBranchId:  repo.Repository.DefaultBranchID.String(),
-		CreationDate:     timestamppb.New(repo.Repository.CreationDate),
-		State:            repo.State,
-		InstanceUid:      repo.InstanceUID,
-		ReadOnly:         repo.Repository.ReadOnly,
+		Id:                repo.RepositoryID.String(),
+		StorageNamespace:  repo.Repository.StorageNamespace.String(),
+		DefaultBranchId:   repo.Repository.DefaultBranchID.String(),
+		CreationDate:      timestamppb.New(repo.Repository.CreationDate),
+		State:             repo.State,
+		InstanceUid:       repo.InstanceUID,
+		ReadOnly:          repo.Repository.ReadOnly,
+		MaintenanceReason: repo.Repository.MaintenanceReason,
 	}
 }
 
@@ -0,0 +1,50 @@
+package graveler
+
+import (
+	"sync"
+
+	lru "github.com/hnlq715/golang-lru"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// BranchRateLimiterSize is the maximal number of branch limiters kept in memory
+	BranchRateLimiterSize = 10000
+
+	branchRateLimiterBurst = 1
+)
+
+// BranchRateLimiter limits the rate of an operation per repository branch, without queuing.
+// Limiters are kept in a bounded LRU cache of BranchRateLimiterSize entries. When more branches are active, the least
+// recently used branch limiter is evicted, and the next operation on that branch starts with a fresh limiter.
+type BranchRateLimiter struct {
+	limit    rate.Limit
+	limiters *lru.Cache
+	mu       sync.Mutex
+}
+
+func NewBranchRateLimiter(limit rate.Limit) *BranchRateLimiter {
+	limiters, err := lru.New(BranchRateLimiterSize)
+	if err != nil {
+		panic(err)
+	}
+	return &BranchRateLimiter{
+		limit:    limit,
+		limiters: limiters,
+	}
+}
+
+// Allow reports whether an operation on the repository branch may happen now, and consumes a token if it may
+func (l *BranchRateLimiter) Allow(repositoryID RepositoryID, branchID BranchID) bool {
+	key := repositoryID.String() + "/" + branchID.String()
+	l.mu.Lock()
+	var limiter *rate.Limiter
+	if v, ok := l.limiters.Get(key); ok {
+		limiter = v.(*rate.Limiter)
+	} else {
+		limiter = rate.NewLimiter(l.limit, branchRateLimiterBurst)
+		l.limiters.Add(key, limiter)
+	}
+	l.mu.Unlock()
+	return limiter.Allow()
+}
@@ -0,0 +1,27 @@
+package graveler_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/treeverse/lakefs/pkg/graveler"
+	"golang.org/x/time/rate"
+)
+
+func TestBranchRateLimiter(t *testing.T) {
+	// a single operation per hour - only the first operation on each branch is allowed
+	limiter := graveler.NewBranchRateLimiter(rate.Every(time.Hour))
+
+	if !limiter.Allow("repo1", "branch1") {
+		t.Fatal("first operation on repo1/branch1 should be allowed")
+	}
+	if limiter.Allow("repo1", "branch1") {
+		t.Fatal("second operation on repo1/branch1 should be limited")
+	}
+	if !limiter.Allow("repo1", "branch2") {
+		t.Fatal("first operation on repo1/branch2 should be allowed")
+	}
+	if !limiter.Allow("repo2", "branch1") {
+		t.Fatal("first operation on repo2/branch1 should be allowed")
+	}
+}
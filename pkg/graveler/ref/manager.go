@@ -340,6 +340,49 @@ func (m *Manager) SetRepositoryMetadata(ctx context.Context, repo *graveler.Repo
 	return kv.SetMsgIf(ctx, m.kvStore, graveler.RepoPartition(repo), []byte(graveler.RepoMetadataPath()), graveler.ProtoFromRepositoryMetadata(newMetadata), pred)
 }
 
+// SetRepositoryDefaultBranch updates the stored repository default branch. The repository record cached by this manager
+// is dropped, records cached by other instances keep the previous default branch until they expire.
+func (m *Manager) SetRepositoryDefaultBranch(ctx context.Context, repo *graveler.RepositoryRecord, branchID graveler.BranchID) error {
+	return m.updateRepositoryData(ctx, repo, func(data *graveler.RepositoryData) {
+		data.DefaultBranchId = branchID.String()
+	})
+}
+
+// SetRepositoryMaintenance updates the stored repository maintenance reason, an empty reason turns maintenance mode
+// off. The repository record cached by this manager is dropped, records cached by other instances keep the previous
+// reason until they expire.
+func (m *Manager) SetRepositoryMaintenance(ctx context.Context, repo *graveler.RepositoryRecord, reason string) error {
+	return m.updateRepositoryData(ctx, repo, func(data *graveler.RepositoryData) {
+		data.MaintenanceReason = reason
+	})
+}
+
+// updateRepositoryData applies update to the stored record of an active repository, conditionally on the record read,
+// and drops the cached repository record
+func (m *Manager) updateRepositoryData(ctx context.Context, repo *graveler.RepositoryRecord, update func(data *graveler.RepositoryData)) error {
+	data := graveler.RepositoryData{}
+	pred, err := kv.GetMsg(ctx, m.kvStore, graveler.RepositoriesPartition(), []byte(graveler.RepoPath(repo.RepositoryID)), &data)
+	if err != nil {
+		if errors.Is(err, kv.ErrNotFound) {
+			err = graveler.ErrRepositoryNotFound
+		}
+		return err
+	}
+	if data.State != graveler.RepositoryState_ACTIVE {
+		return graveler.ErrRepositoryInDeletion
+	}
+	update(&data)
+	err = kv.SetMsgIf(ctx, m.kvStore, graveler.RepositoriesPartition(), []byte(graveler.RepoPath(repo.RepositoryID)), &data, pred)
+	if errors.Is(err, kv.ErrPredicateFailed) {
+		return graveler.ErrPreconditionFailed
+	}
+	if err != nil {
+		return err
+	}
+	m.repoCache.Delete(repo.RepositoryID)
+	return nil
+}
+
 func (m *Manager) ParseRef(ref graveler.Ref) (graveler.RawRef, error) {
 	return ParseRef(ref)
 }
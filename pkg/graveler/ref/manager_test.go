@@ -19,6 +19,7 @@ import (
 	"github.com/treeverse/lakefs/pkg/batch"
 	"github.com/treeverse/lakefs/pkg/graveler"
 	"github.com/treeverse/lakefs/pkg/graveler/ref"
+	gUtils "github.com/treeverse/lakefs/pkg/graveler/testutil"
 	"github.com/treeverse/lakefs/pkg/ident"
 	"github.com/treeverse/lakefs/pkg/kv"
 	"github.com/treeverse/lakefs/pkg/kv/mock"
@@ -1227,6 +1228,86 @@ func TestManager_SetRepositoryMetadata(t *testing.T) {
 	}
 }
 
+func TestManager_SetRepositoryDefaultBranch(t *testing.T) {
+	ctx := context.Background()
+	r, store := testRefManager(t)
+	repository, err := r.CreateRepository(ctx, "repo1", graveler.Repository{
+		StorageNamespace: "s3://",
+		CreationDate:     time.Now(),
+		DefaultBranchID:  "master",
+	})
+	testutil.Must(t, err)
+
+	err = r.SetRepositoryDefaultBranch(ctx, repository, "main")
+	require.NoError(t, err)
+
+	// read the stored record
+	data := graveler.RepositoryData{}
+	_, err = kv.GetMsg(ctx, store, graveler.RepositoriesPartition(), []byte(graveler.RepoPath(repository.RepositoryID)), &data)
+	require.NoError(t, err)
+	require.Equal(t, "main", data.DefaultBranchId)
+
+	err = r.SetRepositoryDefaultBranch(ctx, &graveler.RepositoryRecord{RepositoryID: "no-repo"}, "main")
+	require.ErrorIs(t, err, graveler.ErrRepositoryNotFound)
+}
+
+func TestManager_SetRepositoryDefaultBranchDeleteOldDefault(t *testing.T) {
+	ctx := context.Background()
+	r, _ := testRefManager(t)
+	repository, err := r.CreateRepository(ctx, "repo1", graveler.Repository{
+		StorageNamespace: "s3://",
+		CreationDate:     time.Now(),
+		DefaultBranchID:  "master",
+	})
+	testutil.Must(t, err)
+	testutil.Must(t, r.CreateBranch(ctx, repository, "main", graveler.Branch{CommitID: "c1", StagingToken: "s1"}))
+
+	// cache the repository record before switching the default branch
+	repo, err := r.GetRepository(ctx, repository.RepositoryID)
+	require.NoError(t, err)
+	require.Equal(t, graveler.BranchID("master"), repo.DefaultBranchID)
+
+	require.NoError(t, r.SetRepositoryDefaultBranch(ctx, repo, "main"))
+	repo, err = r.GetRepository(ctx, repository.RepositoryID)
+	require.NoError(t, err)
+	require.Equal(t, graveler.BranchID("main"), repo.DefaultBranchID)
+
+	g := graveler.NewGraveler(nil, &gUtils.StagingFake{}, r, nil, nil, nil)
+	require.NoError(t, g.DeleteBranch(ctx, repo, "master"))
+	_, err = r.GetBranch(ctx, repo, "master")
+	require.ErrorIs(t, err, graveler.ErrBranchNotFound)
+	require.ErrorIs(t, g.DeleteBranch(ctx, repo, "main"), graveler.ErrDeleteDefaultBranch)
+}
+
+func TestManager_SetRepositoryMaintenance(t *testing.T) {
+	ctx := context.Background()
+	r, _ := testRefManager(t)
+	repository, err := r.CreateRepository(ctx, "repo1", graveler.Repository{
+		StorageNamespace: "s3://",
+		CreationDate:     time.Now(),
+		DefaultBranchID:  "main",
+	})
+	testutil.Must(t, err)
+
+	// cache the repository record before setting maintenance mode
+	repo, err := r.GetRepository(ctx, repository.RepositoryID)
+	require.NoError(t, err)
+	require.Empty(t, repo.MaintenanceReason)
+
+	require.NoError(t, r.SetRepositoryMaintenance(ctx, repository, "migration"))
+	repo, err = r.GetRepository(ctx, repository.RepositoryID)
+	require.NoError(t, err)
+	require.Equal(t, "migration", repo.MaintenanceReason)
+
+	require.NoError(t, r.SetRepositoryMaintenance(ctx, repository, ""))
+	repo, err = r.GetRepository(ctx, repository.RepositoryID)
+	require.NoError(t, err)
+	require.Empty(t, repo.MaintenanceReason)
+
+	err = r.SetRepositoryMaintenance(ctx, &graveler.RepositoryRecord{RepositoryID: "no-repo"}, "migration")
+	require.ErrorIs(t, err, graveler.ErrRepositoryNotFound)
+}
+
 func TestManager_GetPullRequest(t *testing.T) {
 	r, store := testRefManager(t)
 	repository, err := r.CreateRepository(context.Background(), "repo1", graveler.Repository{
@@ -3,6 +3,7 @@ package ref
 import (
 	"context"
 	"errors"
+	"fmt"
 	"regexp"
 
 	"github.com/treeverse/lakefs/pkg/graveler"
@@ -28,8 +29,11 @@ func isAFullCommitHash(part string) bool {
 	return len(part) == commitIDStringLength && hashRegexp.MatchString(part)
 }
 
-// revResolve return the first resolve of 'rev' - by hash, branch or tag
+// revResolve return the first resolve of 'rev' - by hash, branch or tag. HEAD resolves to the repository default branch.
 func revResolve(ctx context.Context, store Store, addressProvider ident.AddressProvider, repository *graveler.RepositoryRecord, rev string) (*graveler.ResolvedRef, error) {
+	if rev == graveler.HeadRef {
+		return revResolveHead(ctx, store, addressProvider, repository)
+	}
 	// looking for commit first - a full commit takes precedence over a branch or a tag
 	resolvers := []revResolverFunc{revResolveCommit, revResolveBranch, revResolveTag, revResolveCommitPrefix}
 	for _, resolveHelper := range resolvers {
@@ -42,7 +46,32 @@ func revResolve(ctx context.Context, store Store, addressProvider ident.AddressP
 		}
 	}
 
-	return nil, graveler.ErrNotFound
+	if isAHash(rev) {
+		return nil, fmt.Errorf("no branch, tag or commit named '%s': %w", rev, graveler.ErrRefNotFound)
+	}
+	return nil, fmt.Errorf("no branch or tag named '%s': %w", rev, graveler.ErrRefNotFound)
+}
+
+// revResolveHead resolves HEAD to the repository default branch. A branch or tag named HEAD, created before HEAD was
+// reserved, takes precedence.
+func revResolveHead(ctx context.Context, store Store, addressProvider ident.AddressProvider, repository *graveler.RepositoryRecord) (*graveler.ResolvedRef, error) {
+	for _, resolveHelper := range []revResolverFunc{revResolveBranch, revResolveTag} {
+		r, err := resolveHelper(ctx, store, addressProvider, repository, graveler.HeadRef)
+		if err != nil {
+			return nil, err
+		}
+		if r != nil {
+			return r, nil
+		}
+	}
+	r, err := revResolveBranch(ctx, store, addressProvider, repository, repository.DefaultBranchID.String())
+	if err != nil {
+		return nil, err
+	}
+	if r == nil {
+		return nil, fmt.Errorf("default branch '%s': %w", repository.DefaultBranchID, graveler.ErrBranchNotFound)
+	}
+	return r, nil
 }
 
 func ResolveRawRef(ctx context.Context, store Store, addressProvider ident.AddressProvider, repository *graveler.RepositoryRecord, rawRef graveler.RawRef) (*graveler.ResolvedRef, error) {
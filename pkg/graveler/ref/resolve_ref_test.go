@@ -166,6 +166,19 @@ func TestResolveRawRef(t *testing.T) {
 			Ref:         graveler.Ref("branch1^@"),
 			ExpectedErr: graveler.ErrInvalidRef,
 		},
+		{
+			Name:                   "head",
+			Ref:                    graveler.Ref("HEAD"),
+			ExpectedBranchModifier: graveler.ResolvedBranchModifierNone,
+			ExpectedCommitID:       mainBranch.CommitID,
+			ExpectedToken:          mainBranch.StagingToken,
+		},
+		{
+			Name:                   "head_committed",
+			Ref:                    graveler.Ref("HEAD@"),
+			ExpectedBranchModifier: graveler.ResolvedBranchModifierCommitted,
+			ExpectedCommitID:       mainBranch.CommitID,
+		},
 		{
 			Name:                   "main_staging",
 			Ref:                    graveler.Ref("main$"),
@@ -275,6 +288,48 @@ func TestResolveRawRef(t *testing.T) {
 	}
 }
 
+func TestResolveRawRef_LegacyHead(t *testing.T) {
+	r, _ := testRefManager(t)
+	ctx := context.Background()
+	repository, err := r.CreateRepository(ctx, "repo1", graveler.Repository{
+		StorageNamespace: "s3://",
+		CreationDate:     time.Now(),
+		DefaultBranchID:  "main",
+	})
+	testutil.Must(t, err)
+	commitID, err := r.AddCommit(ctx, repository, graveler.Commit{
+		Committer:    "user1",
+		Message:      "message1",
+		MetaRangeID:  "deadbeef123",
+		CreationDate: time.Now(),
+	})
+	testutil.Must(t, err)
+
+	resolve := func() *graveler.ResolvedRef {
+		t.Helper()
+		rawRef, err := r.ParseRef(graveler.HeadRef)
+		testutil.MustDo(t, "parse HEAD", err)
+		resolvedRef, err := r.ResolveRawRef(ctx, repository, rawRef)
+		testutil.MustDo(t, "resolve HEAD", err)
+		return resolvedRef
+	}
+
+	// a tag named HEAD, created before HEAD was reserved, is resolved instead of the default branch
+	testutil.Must(t, r.CreateTag(ctx, repository, graveler.HeadRef, commitID))
+	if resolved := resolve(); resolved.Type != graveler.ReferenceTypeTag || resolved.CommitID != commitID {
+		t.Fatalf("HEAD resolved to type %d %s, expected tag on commit %s", resolved.Type, resolved.CommitID, commitID)
+	}
+
+	// a branch named HEAD takes precedence over the tag
+	testutil.Must(t, r.SetBranch(ctx, repository, graveler.HeadRef, graveler.Branch{
+		CommitID:     commitID,
+		StagingToken: "head-token",
+	}))
+	if resolved := resolve(); resolved.Type != graveler.ReferenceTypeBranch || resolved.StagingToken != "head-token" {
+		t.Fatalf("HEAD resolved to type %d with token %s, expected branch HEAD", resolved.Type, resolved.StagingToken)
+	}
+}
+
 func TestResolveRef_SameDate(t *testing.T) {
 	r, _ := testRefManager(t)
 	ctx := context.Background()
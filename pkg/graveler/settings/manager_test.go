@@ -45,6 +45,10 @@ func (m *mockCache) GetOrSetWithExpiry(k interface{}, setFn cache.SetFnWithExpir
 	panic("Not implemented.")
 }
 
+func (m *mockCache) Delete(k interface{}) {
+	delete(m.c, k)
+}
+
 func TestNonExistent(t *testing.T) {
 	ctx := context.Background()
 	m := prepareTest(t, ctx, nil, nil)
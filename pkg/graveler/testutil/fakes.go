@@ -23,6 +23,7 @@ type CommittedFake struct {
 	ValueIterator graveler.ValueIterator
 	Values        map[string]graveler.ValueIterator
 	DiffIterator  graveler.DiffIterator
+	DiffSize      int
 	Err           error
 	MetaRangeID   graveler.MetaRangeID
 	RangeInfo     graveler.RangeInfo
@@ -73,6 +74,13 @@ func (c *CommittedFake) Diff(context.Context, graveler.StorageNamespace, gravele
 	return c.DiffIterator, nil
 }
 
+func (c *CommittedFake) EstimateDiffSize(context.Context, graveler.StorageNamespace, graveler.MetaRangeID, graveler.MetaRangeID) (int, error) {
+	if c.Err != nil {
+		return 0, c.Err
+	}
+	return c.DiffSize, nil
+}
+
 func (c *CommittedFake) Compare(context.Context, graveler.StorageNamespace, graveler.MetaRangeID, graveler.MetaRangeID, graveler.MetaRangeID) (graveler.DiffIterator, error) {
 	if c.Err != nil {
 		return nil, c.Err
@@ -885,6 +893,16 @@ func (m *RefsFake) SetRepositoryMetadata(_ context.Context, _ *graveler.Reposito
 	panic("implement me")
 }
 
+func (m *RefsFake) SetRepositoryDefaultBranch(_ context.Context, _ *graveler.RepositoryRecord, _ graveler.BranchID) error {
+	// TODO implement me
+	panic("implement me")
+}
+
+func (m *RefsFake) SetRepositoryMaintenance(_ context.Context, _ *graveler.RepositoryRecord, _ string) error {
+	// TODO implement me
+	panic("implement me")
+}
+
 func (m *RefsFake) CreateCommitRecord(_ context.Context, _ *graveler.RepositoryRecord, _ graveler.CommitID, _ graveler.Commit) error {
 	// TODO implement me
 	panic("implement me")
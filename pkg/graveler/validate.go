@@ -7,6 +7,8 @@ import (
 	"github.com/treeverse/lakefs/pkg/validator"
 )
 
+const commitIDLength = 64
+
 func ValidateStorageNamespace(v interface{}) error {
 	s, ok := v.(StorageNamespace)
 	if !ok {
@@ -47,6 +49,18 @@ func ValidateBranchID(v interface{}) error {
 	return nil
 }
 
+// ValidateNewBranchID validates the name of a branch to create: a valid branch ID that is not the reserved HeadRef.
+// Branches named HEAD created before it was reserved are still valid branch IDs, so they can be read and deleted.
+func ValidateNewBranchID(v interface{}) error {
+	if err := ValidateBranchID(v); err != nil {
+		return err
+	}
+	if v.(BranchID) == HeadRef {
+		return ErrInvalidBranchID
+	}
+	return nil
+}
+
 func ValidateTagID(v interface{}) error {
 	s, ok := v.(TagID)
 	if !ok {
@@ -80,6 +94,38 @@ func ValidateTagID(v interface{}) error {
 	return nil
 }
 
+// ValidateNewTagID validates the name of a tag to create: a valid tag ID that is not the reserved HeadRef.
+// Tags named HEAD created before it was reserved are still valid tag IDs, so they can be read and deleted.
+func ValidateNewTagID(v interface{}) error {
+	if err := ValidateTagID(v); err != nil {
+		return err
+	}
+	if v.(TagID) == HeadRef {
+		return ErrInvalidTagID
+	}
+	return nil
+}
+
+// ValidateCommitID accepts only a full commit ID: 64 lowercase hex digits, as generated for commits.
+func ValidateCommitID(v interface{}) error {
+	s, ok := v.(CommitID)
+	if !ok {
+		panic(ErrInvalidType)
+	}
+	if len(s) == 0 {
+		return ErrRequiredValue
+	}
+	if len(s) != commitIDLength {
+		return ErrInvalidCommitID
+	}
+	for _, r := range s.String() {
+		if !('0' <= r && r <= '9' || 'a' <= r && r <= 'f') {
+			return ErrInvalidCommitID
+		}
+	}
+	return nil
+}
+
 func ValidatePullRequestID(v interface{}) error {
 	s, ok := v.(PullRequestID)
 	if !ok {
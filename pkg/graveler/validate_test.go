@@ -62,6 +62,7 @@ func TestValidateBranchID(t *testing.T) {
 		{name: "alpha underscore numeric", branchID: "valid_123", wantErr: nil},
 		{name: "alpha dash numeric", branchID: "valid-123", wantErr: nil},
 		{name: "alpha numeric", branchID: "123valid", wantErr: nil},
+		{name: "head", branchID: "HEAD", wantErr: nil},
 		{name: "char1", branchID: "invalid~char", wantErr: ErrInvalidValue},
 		{name: "alpha dot numeric", branchID: "invalid1.0", wantErr: ErrInvalidValue},
 		{name: "alpha two dots", branchID: "invalid..branch", wantErr: ErrInvalidValue},
@@ -84,3 +85,68 @@ func TestValidateBranchID(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateNewBranchID(t *testing.T) {
+	tests := []struct {
+		name     string
+		branchID BranchID
+		wantErr  error
+	}{
+		{name: "valid", branchID: "valid-branch", wantErr: nil},
+		{name: "head", branchID: "HEAD", wantErr: ErrInvalidValue},
+		{name: "head prefix", branchID: "HEAD-1", wantErr: nil},
+		{name: "invalid", branchID: "invalid~char", wantErr: ErrInvalidValue},
+	}
+	for _, tb := range tests {
+		t.Run(tb.name, func(t *testing.T) {
+			err := ValidateNewBranchID(tb.branchID)
+			if !errors.Is(err, tb.wantErr) {
+				t.Errorf("ValidateNewBranchID() error = %v, wantErr %v", err, tb.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateNewTagID(t *testing.T) {
+	tests := []struct {
+		name    string
+		tag     TagID
+		wantErr error
+	}{
+		{name: "valid", tag: "v1.0", wantErr: nil},
+		{name: "head", tag: "HEAD", wantErr: ErrInvalidValue},
+		{name: "invalid", tag: "@", wantErr: ErrInvalidValue},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateNewTagID(tt.tag)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ValidateNewTagID() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateCommitID(t *testing.T) {
+	tests := []struct {
+		name     string
+		commitID CommitID
+		wantErr  error
+	}{
+		{name: "empty", commitID: "", wantErr: ErrRequiredValue},
+		{name: "full", commitID: "c2b4a2b17d6e5b5e8f4dd1c6e30e2d6e1b8cfb1c2f0e0d8b5a6a1c9b3e4f5a6b", wantErr: nil},
+		{name: "upper case", commitID: "C2B4A2B17D6E5B5E8F4DD1C6E30E2D6E1B8CFB1C2F0E0D8B5A6A1C9B3E4F5A6B", wantErr: ErrInvalidValue},
+		{name: "mixed case", commitID: "c2b4a2b17d6e5b5e8f4dd1c6e30e2d6e1b8cfb1c2f0e0d8b5a6a1c9b3e4f5A6B", wantErr: ErrInvalidValue},
+		{name: "prefix", commitID: "c2b4a2b", wantErr: ErrInvalidValue},
+		{name: "branch", commitID: "main", wantErr: ErrInvalidValue},
+		{name: "not hex", commitID: "g2b4a2b17d6e5b5e8f4dd1c6e30e2d6e1b8cfb1c2f0e0d8b5a6a1c9b3e4f5a6b", wantErr: ErrInvalidValue},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCommitID(tt.commitID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ValidateCommitID() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}